@@ -19,55 +19,328 @@ package types
 
 import (
 	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 type BackupDaemon struct {
-	Resources              *v1.ResourceRequirements `json:"resources,omitempty"`
-	DockerImage            string                   `json:"image,omitempty"`
-	Affinity               v1.Affinity              `json:"affinity,omitempty"`
-	Storage                Storage                  `json:"storage,omitempty"`
-	PgHost                 string                   `json:"pgHost,omitempty"`
-	EvictionPolicy         string                   `json:"evictionPolicy,omitempty"`
-	BackupSchedule         string                   `json:"backupSchedule,omitempty"`
-	GranularEviction       string                   `json:"granularEviction,omitempty"`
-	JobFlag                string                   `json:"jobFlag,omitempty"`
-	ConnectTimeout         string                   `json:"connectTimeout,omitempty"`
-	GranularBackupSchedule string                   `json:"granularBackupSchedule,omitempty"`
-	DatabasesToSchedule    string                   `json:"databasesToSchedule,omitempty"`
-	WalArchiving           bool                     `json:"walArchiving,omitempty"`
-	AllowPrefix            bool                     `json:"allowPrefix,omitempty"`
-	ExcludedExtensions     string                   `json:"excludedExtensions,omitempty"`
-	CompressionLevel       int                      `json:"compressionLevel"`
-	Encryption             bool                     `json:"encryption,omitempty"`
-	RetainArchiveSettings  bool                     `json:"retainArchiveSettings,omitempty"`
-	BackupTimeout          int                      `json:"backupTimeout,omitempty"`
-	UseEvictionPolicyFirst string                   `json:"useEvictionPolicyFirst,omitempty"`
-	EvictionBinaryPolicy   string                   `json:"evictionBinaryPolicy,omitempty"`
-	ArchiveEvictionPolicy  string                   `json:"archiveEvictionPolicy,omitempty"`
-	SecurityContext        v1.PodSecurityContext    `json:"securityContext,omitempty"`
-	PriorityClassName      string                   `json:"priorityClassName,omitempty"`
-	S3Storage              *S3Storage               `json:"s3Storage,omitempty"`
-	PodLabels              map[string]string        `json:"podLabels,omitempty"`
-	ExternalPv             *ExternalPv              `json:"externalPv,omitempty"`
-	SslMode                string                   `json:"sslMode,omitempty"`
+	Resources                 *v1.ResourceRequirements      `json:"resources,omitempty"`
+	DockerImage               string                        `json:"image,omitempty"`
+	Affinity                  v1.Affinity                   `json:"affinity,omitempty"`
+	Storage                   Storage                       `json:"storage,omitempty"`
+	PgHost                    string                        `json:"pgHost,omitempty"`
+	EvictionPolicy            string                        `json:"evictionPolicy,omitempty"`
+	BackupSchedule            string                        `json:"backupSchedule,omitempty"`
+	GranularEviction          string                        `json:"granularEviction,omitempty"`
+	JobFlag                   string                        `json:"jobFlag,omitempty"`
+	ConnectTimeout            string                        `json:"connectTimeout,omitempty"`
+	GranularBackupSchedule    string                        `json:"granularBackupSchedule,omitempty"`
+	DatabasesToSchedule       string                        `json:"databasesToSchedule,omitempty"`
+	WalArchiving              bool                          `json:"walArchiving,omitempty"`
+	AllowPrefix               bool                          `json:"allowPrefix,omitempty"`
+	ExcludedExtensions        string                        `json:"excludedExtensions,omitempty"`
+	CompressionLevel          int                           `json:"compressionLevel"`
+	Encryption                bool                          `json:"encryption,omitempty"`
+	RetainArchiveSettings     bool                          `json:"retainArchiveSettings,omitempty"`
+	BackupTimeout             int                           `json:"backupTimeout,omitempty"`
+	UseEvictionPolicyFirst    string                        `json:"useEvictionPolicyFirst,omitempty"`
+	EvictionBinaryPolicy      string                        `json:"evictionBinaryPolicy,omitempty"`
+	ArchiveEvictionPolicy     string                        `json:"archiveEvictionPolicy,omitempty"`
+	SecurityContext           v1.PodSecurityContext         `json:"securityContext,omitempty"`
+	PriorityClassName         string                        `json:"priorityClassName,omitempty"`
+	S3Storage                 *S3Storage                    `json:"s3Storage,omitempty"`
+	PodLabels                 map[string]string             `json:"podLabels,omitempty"`
+	ExternalPv                *ExternalPv                   `json:"externalPv,omitempty"`
+	SslMode                   string                        `json:"sslMode,omitempty"`
+	Tolerations               []v1.Toleration               `json:"tolerations,omitempty"`
+	TopologySpreadConstraints []v1.TopologySpreadConstraint `json:"topologySpreadConstraints,omitempty"`
+	NodeSelector              map[string]string             `json:"nodeSelector,omitempty"`
+	RuntimeClassName          string                        `json:"runtimeClassName,omitempty"`
+	LivenessProbe             *ProbeSettings                `json:"livenessProbe,omitempty"`
+	ReadinessProbe            *ProbeSettings                `json:"readinessProbe,omitempty"`
+	StartupProbe              *ProbeSettings                `json:"startupProbe,omitempty"`
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=64
+	PublicEndpointsWorkers int `json:"publicEndpointsWorkers,omitempty"`
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=64
+	PrivateEndpointsWorkers int `json:"privateEndpointsWorkers,omitempty"`
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=64
+	ArchiveEndpointsWorkers int `json:"archiveEndpointsWorkers,omitempty"`
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=65535
+	PgPort       int                `json:"pgPort,omitempty"`
+	Auth         *BackupDaemonAuth  `json:"auth,omitempty"`
+	Tls          *BackupDaemonTls   `json:"tls,omitempty"`
+	Ssl          *PgSsl             `json:"ssl,omitempty"`
+	GcsStorage   *GcsStorage        `json:"gcsStorage,omitempty"`
+	AzureStorage *AzureStorage      `json:"azureStorage,omitempty"`
+	CopyTargets  []BackupCopyTarget `json:"copyTargets,omitempty"`
+	// Timezone of BackupSchedule and GranularBackupSchedule, e.g. "Europe/Berlin", UTC by default
+	Timezone string `json:"timezone,omitempty"`
+	// GranularSchedules replace DatabasesToSchedule and GranularBackupSchedule, which must be empty then
+	GranularSchedules []GranularSchedule `json:"granularSchedules,omitempty"`
+	// GranularDumpOptions.ExcludedExtensions replaces ExcludedExtensions when set
+	GranularDumpOptions *GranularDumpOptions `json:"granularDumpOptions,omitempty"`
+	// CompressionAlgorithm of backups, gzip by default. CompressionLevel range depends on it
+	// +kubebuilder:validation:Enum=gzip;zstd;lz4;none
+	CompressionAlgorithm string               `json:"compressionAlgorithm,omitempty"`
+	BackupWindows        *BackupWindows       `json:"backupWindows,omitempty"`
+	Hooks                *BackupHooks         `json:"hooks,omitempty"`
+	Notifications        *BackupNotifications `json:"notifications,omitempty"`
+	Throttling           *BackupThrottling    `json:"throttling,omitempty"`
+	SizeRetention        *SizeRetention       `json:"sizeRetention,omitempty"`
+}
+
+// SizeRetention evicts the oldest full backups once their total size exceeds the smaller of
+// Quota and MaxPercent of Storage.Size. It is applied after EvictionPolicy.
+type SizeRetention struct {
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=100
+	MaxPercent int `json:"maxPercent,omitempty"`
+	// +kubebuilder:validation:Pattern=`^[0-9]+(Ki|Mi|Gi|Ti|Pi|Ei|k|M|G|T|P|E)$`
+	Quota string `json:"quota,omitempty"`
+	// MinFullBackups are kept regardless of their size, 1 by default
+	// +kubebuilder:validation:Minimum=1
+	MinFullBackups int `json:"minFullBackups,omitempty"`
+}
+
+// BackupThrottling limits resources used by backups, rates are quantities per second, e.g. "50Mi".
+type BackupThrottling struct {
+	// +kubebuilder:validation:Pattern=`^[0-9]+(Ki|Mi|Gi|k|M|G)?$`
+	UploadBandwidth string `json:"uploadBandwidth,omitempty"`
+	// ReadRate is passed to pg_basebackup --max-rate
+	// +kubebuilder:validation:Pattern=`^[0-9]+(Ki|Mi|Gi|k|M|G)?$`
+	ReadRate string `json:"readRate,omitempty"`
+	// GranularConcurrency is the number of databases dumped at the same time
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=64
+	GranularConcurrency int         `json:"granularConcurrency,omitempty"`
+	IoPriority          *IoPriority `json:"ioPriority,omitempty"`
+}
+
+// IoPriority sets ionice class and level of backup processes, BlockIoClass is the
+// block IO class of the pod configured in the container runtime.
+type IoPriority struct {
+	// +kubebuilder:validation:Enum=best-effort;idle
+	Class string `json:"class,omitempty"`
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=7
+	Level        *int   `json:"level,omitempty"`
+	BlockIoClass string `json:"blockIoClass,omitempty"`
+}
+
+// BackupNotifications are sent by the daemon itself, or by the notifier sidecar when its image is set.
+type BackupNotifications struct {
+	Targets  []NotificationTarget `json:"targets,omitempty"`
+	Notifier *NotifierSidecar     `json:"notifier,omitempty"`
+}
+
+// NotificationTarget is a webhook, the URL is read from a Secret as it usually embeds a token.
+// Empty Events subscribes the target to all events.
+type NotificationTarget struct {
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`
+	Name string `json:"name"`
+	// +kubebuilder:validation:Enum=http;slack;teams
+	Type string                `json:"type"`
+	Url  *v1.SecretKeySelector `json:"url"`
+	// +kubebuilder:validation:items:Enum=success;failure;eviction
+	Events []string `json:"events,omitempty"`
+}
+
+type NotifierSidecar struct {
+	DockerImage string                   `json:"image,omitempty"`
+	Resources   *v1.ResourceRequirements `json:"resources,omitempty"`
+}
+
+type BackupHooks struct {
+	PreBackup  []BackupHook `json:"preBackup,omitempty"`
+	PostBackup []BackupHook `json:"postBackup,omitempty"`
+}
+
+// BackupHook runs either SQL statements, a command in the daemon container or an HTTP callback.
+// Timeout is an age such as "30s".
+type BackupHook struct {
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`
+	Name    string    `json:"name"`
+	Sql     []string  `json:"sql,omitempty"`
+	Exec    []string  `json:"exec,omitempty"`
+	Http    *HttpHook `json:"http,omitempty"`
+	Timeout string    `json:"timeout,omitempty"`
+	// FailurePolicy abort fails the backup when the hook fails
+	// +kubebuilder:validation:Enum=abort;continue
+	FailurePolicy string `json:"failurePolicy,omitempty"`
+}
+
+type HttpHook struct {
+	Url string `json:"url"`
+	// +kubebuilder:validation:Enum=GET;POST;PUT;PATCH;DELETE
+	Method  string       `json:"method,omitempty"`
+	Headers []HttpHeader `json:"headers,omitempty"`
+	Body    string       `json:"body,omitempty"`
+}
+
+// HttpHeader has either Value or ValueFrom, which keeps tokens out of the hooks config.
+type HttpHeader struct {
+	Name      string                `json:"name"`
+	Value     string                `json:"value,omitempty"`
+	ValueFrom *v1.SecretKeySelector `json:"valueFrom,omitempty"`
+}
+
+// BackupWindows restricts scheduled backups to Allowed windows, any time when empty,
+// and keeps them out of Blackouts. Times are in the daemon Timezone.
+type BackupWindows struct {
+	Allowed   []TimeWindow     `json:"allowed,omitempty"`
+	Blackouts []BlackoutPeriod `json:"blackouts,omitempty"`
+	// Policy for backups outside allowed time, defer moves them to the next allowed time
+	// +kubebuilder:validation:Enum=defer;skip
+	Policy string `json:"policy,omitempty"`
+}
+
+// TimeWindow is a daily window between Start and End in "15:04" format, End before Start
+// spans midnight. Empty Days means every day.
+type TimeWindow struct {
+	Days  []string `json:"days,omitempty"`
+	Start string   `json:"start"`
+	End   string   `json:"end"`
+}
+
+// BlackoutPeriod is either recurring, starting by Schedule cron for Duration such as "2d",
+// or a one-off period between From and To.
+type BlackoutPeriod struct {
+	Name     string       `json:"name,omitempty"`
+	Schedule string       `json:"schedule,omitempty"`
+	Duration string       `json:"duration,omitempty"`
+	From     *metav1.Time `json:"from,omitempty"`
+	To       *metav1.Time `json:"to,omitempty"`
+}
+
+// GranularDumpOptions are pg_dump options of granular backups.
+// Jobs are supported by directory format only.
+type GranularDumpOptions struct {
+	// +kubebuilder:validation:Enum=custom;directory;tar;plain
+	Format string `json:"format,omitempty"`
+	// +kubebuilder:validation:Minimum=0
+	Jobs               int      `json:"jobs,omitempty"`
+	ExcludedExtensions []string `json:"excludedExtensions,omitempty"`
+	Schemas            []string `json:"schemas,omitempty"`
+	ExcludedSchemas    []string `json:"excludedSchemas,omitempty"`
+	ExcludedTables     []string `json:"excludedTables,omitempty"`
+	NoOwner            bool     `json:"noOwner,omitempty"`
+	NoPrivileges       bool     `json:"noPrivileges,omitempty"`
+}
+
+// GranularSchedule is a granular backup schedule of a database, or of all databases matching
+// DatabasePattern except those matching Exclude. Retention is an age such as "7d".
+// DumpOptions replace GranularDumpOptions for the matched databases, except ExcludedExtensions.
+type GranularSchedule struct {
+	Database        string               `json:"database,omitempty"`
+	DatabasePattern string               `json:"databasePattern,omitempty"`
+	Exclude         []string             `json:"exclude,omitempty"`
+	Schedule        string               `json:"schedule"`
+	Retention       string               `json:"retention,omitempty"`
+	DumpOptions     *GranularDumpOptions `json:"dumpOptions,omitempty"`
+}
+
+// BackupCopyTarget is a secondary storage every completed backup is copied to.
+// EvictionPolicy is applied to the copies independently of the primary storage.
+type BackupCopyTarget struct {
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`
+	Name string `json:"name"`
+	// +kubebuilder:validation:Enum=s3;gcs;azure;pv
+	Type           string        `json:"type"`
+	S3Storage      *S3Storage    `json:"s3Storage,omitempty"`
+	GcsStorage     *GcsStorage   `json:"gcsStorage,omitempty"`
+	AzureStorage   *AzureStorage `json:"azureStorage,omitempty"`
+	ClaimName      string        `json:"claimName,omitempty"`
+	EvictionPolicy string        `json:"evictionPolicy,omitempty"`
+}
+
+// BackupDaemonStatus is meant to be embedded into status of the resource owning BackupDaemon
+type BackupDaemonStatus struct {
+	CopyTargets []BackupCopyStatus `json:"copyTargets,omitempty"`
+}
+
+// BackupCopyStatus reports replication of backups to a copy target
+type BackupCopyStatus struct {
+	Name             string       `json:"name"`
+	LastBackup       string       `json:"lastBackup,omitempty"`
+	LastCopiedBackup string       `json:"lastCopiedBackup,omitempty"`
+	LastCopyTime     *metav1.Time `json:"lastCopyTime,omitempty"`
+	LagSeconds       int64        `json:"lagSeconds,omitempty"`
+	Error            string       `json:"error,omitempty"`
+}
+
+// BackupDaemonAuth protects backup daemon endpoints with credentials from a Secret.
+// Empty SecretName uses the Secret generated by the operator.
+type BackupDaemonAuth struct {
+	Enabled bool `json:"enabled,omitempty"`
+	// +kubebuilder:validation:Enum=basic;token
+	Type       string `json:"type,omitempty"`
+	SecretName string `json:"secretName,omitempty"`
+}
+
+// BackupDaemonTls serves backup daemon endpoints over HTTPS. The key pair is taken from
+// SecretName when set, otherwise it is issued by cert-manager with Issuer.
+type BackupDaemonTls struct {
+	Enabled    bool       `json:"enabled,omitempty"`
+	SecretName string     `json:"secretName,omitempty"`
+	Issuer     *IssuerRef `json:"issuer,omitempty"`
+	DnsNames   []string   `json:"dnsNames,omitempty"`
+}
+
+// IssuerRef references a cert-manager Issuer or ClusterIssuer
+type IssuerRef struct {
+	Name string `json:"name,omitempty"`
+	// +kubebuilder:validation:Enum=Issuer;ClusterIssuer
+	Kind  string `json:"kind,omitempty"`
+	Group string `json:"group,omitempty"`
 }
 
 type MetricCollector struct {
-	Resources             *v1.ResourceRequirements `json:"resources,omitempty"`
-	DockerImage           string                   `json:"image,omitempty"`
-	Affinity              v1.Affinity              `json:"affinity,omitempty"`
-	InfluxDbHost          string                   `json:"influxDbHost,omitempty"`
-	InfluxDatabase        string                   `json:"influxDatabase,omitempty"`
-	MetricsProfile        string                   `json:"metricsProfile,omitempty"`
-	CollectionInterval    int                      `json:"collectionInterval,omitempty"`
-	SecurityContext       v1.PodSecurityContext    `json:"securityContext,omitempty"`
-	TelegrafPluginTimeout int                      `json:"telegrafPluginTimeout,omitempty"`
-	DevMetricsTimeout     int                      `json:"devMetricsTimeout,omitempty"`
-	DevMetricsInterval    int                      `json:"devMetricsInterval,omitempty"`
-	PriorityClassName     string                   `json:"priorityClassName,omitempty"`
-	OcExecTimeout         int                      `json:"ocExecTimeout,omitempty"`
-	PodLabels             map[string]string        `json:"podLabels,omitempty"`
-	SslMode               string                   `json:"sslMode,omitempty"`
+	Resources                 *v1.ResourceRequirements      `json:"resources,omitempty"`
+	DockerImage               string                        `json:"image,omitempty"`
+	Affinity                  v1.Affinity                   `json:"affinity,omitempty"`
+	InfluxDbHost              string                        `json:"influxDbHost,omitempty"`
+	InfluxDatabase            string                        `json:"influxDatabase,omitempty"`
+	MetricsProfile            string                        `json:"metricsProfile,omitempty"`
+	CollectionInterval        int                           `json:"collectionInterval,omitempty"`
+	SecurityContext           v1.PodSecurityContext         `json:"securityContext,omitempty"`
+	TelegrafPluginTimeout     int                           `json:"telegrafPluginTimeout,omitempty"`
+	DevMetricsTimeout         int                           `json:"devMetricsTimeout,omitempty"`
+	DevMetricsInterval        int                           `json:"devMetricsInterval,omitempty"`
+	PriorityClassName         string                        `json:"priorityClassName,omitempty"`
+	OcExecTimeout             int                           `json:"ocExecTimeout,omitempty"`
+	PodLabels                 map[string]string             `json:"podLabels,omitempty"`
+	SslMode                   string                        `json:"sslMode,omitempty"`
+	Tolerations               []v1.Toleration               `json:"tolerations,omitempty"`
+	TopologySpreadConstraints []v1.TopologySpreadConstraint `json:"topologySpreadConstraints,omitempty"`
+	NodeSelector              map[string]string             `json:"nodeSelector,omitempty"`
+	RuntimeClassName          string                        `json:"runtimeClassName,omitempty"`
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=65535
+	PgPort int    `json:"pgPort,omitempty"`
+	Ssl    *PgSsl `json:"ssl,omitempty"`
+}
+
+// PgSsl references the files libpq needs for verify-ca and verify-full SslMode
+// and for client certificate authentication.
+type PgSsl struct {
+	Ca   *v1.SecretKeySelector `json:"ca,omitempty"`
+	Cert *v1.SecretKeySelector `json:"cert,omitempty"`
+	Key  *v1.SecretKeySelector `json:"key,omitempty"`
+}
+
+// ProbeSettings overrides timings of a predefined probe, zero values keep defaults
+type ProbeSettings struct {
+	// +kubebuilder:validation:Minimum=0
+	InitialDelaySeconds int32 `json:"initialDelaySeconds,omitempty"`
+	// +kubebuilder:validation:Minimum=0
+	PeriodSeconds int32 `json:"periodSeconds,omitempty"`
+	// +kubebuilder:validation:Minimum=0
+	TimeoutSeconds int32 `json:"timeoutSeconds,omitempty"`
+	// +kubebuilder:validation:Minimum=0
+	FailureThreshold int32 `json:"failureThreshold,omitempty"`
 }
 
 // Vault DbEngine configuration
@@ -89,6 +362,9 @@ type Storage struct {
 	Nodes        []string `json:"nodes,omitempty"`
 	Selectors    []string `json:"selectors,omitempty"`
 	AccessModes  []string `json:"accessModes,omitempty"`
+	// NodeFailover allows the pod to be rescheduled to any of Nodes. Set it only when
+	// the volumes are reachable from all of them, hostPath and local volumes are not.
+	NodeFailover bool `json:"nodeFailover,omitempty"`
 }
 
 type VaultRegistration struct {
@@ -121,13 +397,64 @@ type CloudSql struct {
 }
 
 type S3Storage struct {
-	Url             string `json:"url,omitempty"`
-	AccessKeyId     string `json:"accessKeyId,omitempty"`
-	SecretAccessKey string `json:"secretAccessKey,omitempty"`
-	Bucket          string `json:"bucket,omitempty"`
-	Prefix          string `json:"prefix,omitempty"`
-	UntrustedCert   bool   `json:"untrustedCert,omitempty"`
-	Region          string `json:"region,omitempty"`
+	Url             string         `json:"url,omitempty"`
+	AccessKeyId     string         `json:"accessKeyId,omitempty"`
+	SecretAccessKey string         `json:"secretAccessKey,omitempty"`
+	Bucket          string         `json:"bucket,omitempty"`
+	Prefix          string         `json:"prefix,omitempty"`
+	UntrustedCert   bool           `json:"untrustedCert,omitempty"`
+	Region          string         `json:"region,omitempty"`
+	CaBundle        *CaBundle      `json:"caBundle,omitempty"`
+	WebIdentity     *S3WebIdentity `json:"webIdentity,omitempty"`
+	// +kubebuilder:validation:Enum=auto;path;virtual
+	AddressingStyle      string                  `json:"addressingStyle,omitempty"`
+	ServerSideEncryption *S3ServerSideEncryption `json:"serverSideEncryption,omitempty"`
+	StorageClass         string                  `json:"storageClass,omitempty"`
+	ObjectLock           *S3ObjectLock           `json:"objectLock,omitempty"`
+}
+
+// S3ObjectLock keeps uploaded backups immutable until Retention, given as "30d", expires.
+// The bucket must have object lock enabled.
+type S3ObjectLock struct {
+	// +kubebuilder:validation:Enum=governance;compliance
+	Mode      string `json:"mode,omitempty"`
+	Retention string `json:"retention,omitempty"`
+}
+
+// S3WebIdentity obtains temporary credentials with a projected service account token
+// instead of static access keys, as IRSA on EKS and STS on OpenShift do.
+type S3WebIdentity struct {
+	RoleArn  string `json:"roleArn,omitempty"`
+	Audience string `json:"audience,omitempty"`
+}
+
+type S3ServerSideEncryption struct {
+	// +kubebuilder:validation:Enum=SSE-S3;SSE-KMS
+	Type     string `json:"type,omitempty"`
+	KmsKeyId string `json:"kmsKeyId,omitempty"`
+}
+
+// CaBundle references PEM encoded CA certificates in a ConfigMap or a Secret
+type CaBundle struct {
+	ConfigMap *v1.ConfigMapKeySelector `json:"configMap,omitempty"`
+	Secret    *v1.SecretKeySelector    `json:"secret,omitempty"`
+}
+
+// GcsStorage stores backups in Google Cloud Storage. Empty Credentials uses workload identity.
+type GcsStorage struct {
+	Bucket      string                `json:"bucket,omitempty"`
+	Prefix      string                `json:"prefix,omitempty"`
+	Credentials *v1.SecretKeySelector `json:"credentials,omitempty"`
+}
+
+// AzureStorage stores backups in Azure Blob Storage with either an account key or a SAS token.
+type AzureStorage struct {
+	AccountName string                `json:"accountName,omitempty"`
+	Container   string                `json:"container,omitempty"`
+	Prefix      string                `json:"prefix,omitempty"`
+	Endpoint    string                `json:"endpoint,omitempty"`
+	AccountKey  *v1.SecretKeySelector `json:"accountKey,omitempty"`
+	SasToken    *v1.SecretKeySelector `json:"sasToken,omitempty"`
 }
 
 type ExternalPv struct {
@@ -3,6 +3,7 @@ module github.com/Netcracker/pgskipper-operator-core
 go 1.22.0
 
 require (
+	github.com/robfig/cron/v3 v3.0.1
 	go.uber.org/zap v1.21.0
 	k8s.io/api v0.31.1
 	k8s.io/apimachinery v0.31.1
@@ -0,0 +1,286 @@
+// Copyright 2024-2025 NetCracker Technology Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package eviction parses backup daemon eviction policies and simulates which backups they keep.
+//
+// A policy is a comma separated list of rules "<start>/<interval>", e.g. "1d/1d,7d/1w,1y/delete".
+// Backups younger than the start of the first rule are kept. A backup older than the start of a rule
+// and younger than the start of the next one is thinned out to one backup per interval,
+// "delete" interval evicts all of them.
+package eviction
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Netcracker/pgskipper-operator-core/pkg/util"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+const (
+	deleteInterval = "delete"
+)
+
+type Rule struct {
+	Start    time.Duration
+	Interval time.Duration
+	Delete   bool
+}
+
+func (r Rule) String() string {
+	interval := deleteInterval
+	if !r.Delete {
+		interval = formatAge(r.Interval)
+	}
+	return fmt.Sprintf("%s/%s", formatAge(r.Start), interval)
+}
+
+type Policy struct {
+	Rules []Rule
+}
+
+// Result of a simulation, both lists are ordered from the oldest backup.
+type Result struct {
+	Kept    []time.Time
+	Evicted []time.Time
+}
+
+// Parse parses and validates an eviction policy.
+func Parse(policy string) (*Policy, error) {
+	if strings.TrimSpace(policy) == "" {
+		return nil, fmt.Errorf("eviction policy is empty")
+	}
+	result := &Policy{}
+	for _, rawRule := range strings.Split(policy, ",") {
+		rule, err := parseRule(strings.TrimSpace(rawRule))
+		if err != nil {
+			return nil, err
+		}
+		result.Rules = append(result.Rules, rule)
+	}
+	if err := result.Validate(); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func parseRule(rule string) (Rule, error) {
+	parts := strings.Split(rule, "/")
+	if len(parts) != 2 {
+		return Rule{}, fmt.Errorf("invalid rule %q, expected <start>/<interval>", rule)
+	}
+	start, err := parseAge(parts[0])
+	if err != nil {
+		return Rule{}, fmt.Errorf("invalid rule %q: %w", rule, err)
+	}
+	if parts[1] == deleteInterval {
+		return Rule{Start: start, Delete: true}, nil
+	}
+	interval, err := parseAge(parts[1])
+	if err != nil {
+		return Rule{}, fmt.Errorf("invalid rule %q: %w", rule, err)
+	}
+	return Rule{Start: start, Interval: interval}, nil
+}
+
+// Validate checks that rules are ordered by start, intervals are positive
+// and nothing follows a delete rule.
+func (p *Policy) Validate() error {
+	if len(p.Rules) == 0 {
+		return fmt.Errorf("eviction policy has no rules")
+	}
+	for i, rule := range p.Rules {
+		if !rule.Delete && rule.Interval <= 0 {
+			return fmt.Errorf("rule %s: interval must be positive", rule)
+		}
+		if i == 0 {
+			continue
+		}
+		previous := p.Rules[i-1]
+		if rule.Start <= previous.Start {
+			return fmt.Errorf("rule %s: start must be greater than start of rule %s", rule, previous)
+		}
+		if previous.Delete {
+			return fmt.Errorf("rule %s: rule %s already deletes all older backups", rule, previous)
+		}
+	}
+	return nil
+}
+
+func (p *Policy) String() string {
+	rules := make([]string, 0, len(p.Rules))
+	for _, rule := range p.Rules {
+		rules = append(rules, rule.String())
+	}
+	return strings.Join(rules, ",")
+}
+
+// MinAge returns the age before which no backup is evicted.
+func (p *Policy) MinAge() time.Duration {
+	return p.Rules[0].Start
+}
+
+// Simulate splits backups by their timestamps into kept and evicted at the moment now.
+// Within an interval the oldest backup is kept, so kept backups stay kept as time goes on.
+func (p *Policy) Simulate(now time.Time, backups []time.Time) Result {
+	sorted := append([]time.Time{}, backups...)
+	sortTimes(sorted)
+
+	result := Result{}
+	keptBuckets := map[string]bool{}
+	for _, backup := range sorted {
+		rule, ok := p.ruleFor(now.Sub(backup))
+		switch {
+		case !ok:
+			result.Kept = append(result.Kept, backup)
+		case rule.Delete:
+			result.Evicted = append(result.Evicted, backup)
+		default:
+			bucket := fmt.Sprintf("%s:%d", rule, backup.UnixNano()/int64(rule.Interval))
+			if keptBuckets[bucket] {
+				result.Evicted = append(result.Evicted, backup)
+			} else {
+				keptBuckets[bucket] = true
+				result.Kept = append(result.Kept, backup)
+			}
+		}
+	}
+	return result
+}
+
+// ruleFor returns the rule with the greatest start not exceeding age.
+func (p *Policy) ruleFor(age time.Duration) (Rule, bool) {
+	for i := len(p.Rules) - 1; i >= 0; i-- {
+		if age >= p.Rules[i].Start {
+			return p.Rules[i], true
+		}
+	}
+	return Rule{}, false
+}
+
+// ParseGranular parses granular eviction, either seconds or an age such as "7d".
+func ParseGranular(eviction string) (time.Duration, error) {
+	if seconds, err := strconv.Atoi(eviction); err == nil {
+		if seconds < 0 {
+			return 0, fmt.Errorf("granular eviction must not be negative")
+		}
+		return time.Duration(seconds) * time.Second, nil
+	}
+	return util.ParseAge(eviction)
+}
+
+// ValidateArchive checks WAL archive eviction, either an age such as "7d" or a size such as "10Gi".
+func ValidateArchive(eviction string) error {
+	if _, err := util.ParseAge(eviction); err == nil {
+		return nil
+	}
+	if _, err := resource.ParseQuantity(eviction); err != nil {
+		return fmt.Errorf("invalid archive eviction %q, expected age or size", eviction)
+	}
+	return nil
+}
+
+func parseAge(age string) (time.Duration, error) {
+	if age == "0" {
+		return 0, nil
+	}
+	return util.ParseAge(age)
+}
+
+func formatAge(age time.Duration) string {
+	if age == 0 {
+		return "0"
+	}
+	for _, unit := range []struct {
+		suffix   string
+		duration time.Duration
+	}{
+		{"y", 365 * 24 * time.Hour},
+		{"w", 7 * 24 * time.Hour},
+		{"d", 24 * time.Hour},
+		{"h", time.Hour},
+		{"m", time.Minute},
+	} {
+		if age%unit.duration == 0 {
+			return fmt.Sprintf("%d%s", age/unit.duration, unit.suffix)
+		}
+	}
+	return fmt.Sprintf("%ds", age/time.Second)
+}
+
+// SizeLimit evicts the oldest backups while their total size exceeds Quota,
+// at least MinKept newest backups are always kept.
+type SizeLimit struct {
+	Quota   int64
+	MinKept int
+}
+
+// ParseQuota returns the smaller of absolute quota and percent of storage size,
+// either of them may be unset.
+func ParseQuota(quota string, percent int, storageSize string) (int64, error) {
+	var result int64
+	if quota != "" {
+		parsed, err := resource.ParseQuantity(quota)
+		if err != nil {
+			return 0, fmt.Errorf("invalid quota %q: %w", quota, err)
+		}
+		result = parsed.Value()
+	}
+	if percent != 0 {
+		if percent < 0 || percent > 100 {
+			return 0, fmt.Errorf("percent must be between 1 and 100")
+		}
+		if storageSize == "" {
+			return 0, fmt.Errorf("percent requires storage size")
+		}
+		size, err := resource.ParseQuantity(storageSize)
+		if err != nil {
+			return 0, fmt.Errorf("invalid storage size %q: %w", storageSize, err)
+		}
+		if limit := size.Value() * int64(percent) / 100; result == 0 || limit < result {
+			result = limit
+		}
+	}
+	if result <= 0 {
+		return 0, fmt.Errorf("quota must be positive")
+	}
+	return result, nil
+}
+
+// Apply evicts kept backups of result over the limit, so it runs after the age based policy.
+// Sizes are keyed by UnixNano of backup time, backups missing in sizes are counted as empty.
+func (l SizeLimit) Apply(result Result, sizes map[int64]int64) Result {
+	kept := append([]time.Time{}, result.Kept...)
+	sortTimes(kept)
+
+	var total int64
+	for _, backup := range kept {
+		total += sizes[backup.UnixNano()]
+	}
+	evicted := append([]time.Time{}, result.Evicted...)
+	for total > l.Quota && len(kept) > l.MinKept {
+		total -= sizes[kept[0].UnixNano()]
+		evicted = append(evicted, kept[0])
+		kept = kept[1:]
+	}
+	sortTimes(evicted)
+	return Result{Kept: kept, Evicted: evicted}
+}
+
+func sortTimes(times []time.Time) {
+	sort.Slice(times, func(i, j int) bool { return times[i].Before(times[j]) })
+}
@@ -0,0 +1,137 @@
+// Copyright 2024-2025 NetCracker Technology Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package eviction
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParse(t *testing.T) {
+	tests := map[string]string{
+		"1d/1d,7d/1w,1y/delete": "1d/1d,1w/1w,1y/delete",
+		" 1d/1d , 7d/delete ":   "1d/1d,1w/delete",
+		"0/1h":                  "0/1h",
+		"24h/168h":              "1d/1w",
+		"90s/delete":            "90s/delete",
+	}
+	for spec, expected := range tests {
+		policy, err := Parse(spec)
+		if err != nil {
+			t.Errorf("Parse(%q): %v", spec, err)
+			continue
+		}
+		if policy.String() != expected {
+			t.Errorf("Parse(%q) = %s, expected %s", spec, policy, expected)
+		}
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	for _, spec := range []string{
+		"",
+		"1d",
+		"1d/1d/1d",
+		"x/1d",
+		"1d/1x",
+		"1d/0",
+		"7d/1d,1d/1d",
+		"1d/1d,1d/1w",
+		"1d/delete,7d/1w",
+	} {
+		if _, err := Parse(spec); err == nil {
+			t.Errorf("Parse(%q) expected error", spec)
+		}
+	}
+}
+
+func TestMinAge(t *testing.T) {
+	policy, err := Parse("2d/1d,7d/delete")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if policy.MinAge() != 48*time.Hour {
+		t.Errorf("MinAge() = %s", policy.MinAge())
+	}
+}
+
+func TestSimulate(t *testing.T) {
+	policy, err := Parse("1d/1d,7d/delete")
+	if err != nil {
+		t.Fatal(err)
+	}
+	date := func(value string) time.Time {
+		parsed, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return parsed
+	}
+	backups := []time.Time{
+		date("2025-03-10T11:00:00Z"),
+		date("2025-03-08T05:00:00Z"),
+		date("2025-03-01T00:00:00Z"),
+		date("2025-03-08T01:00:00Z"),
+		date("2025-03-10T10:00:00Z"),
+		date("2025-03-08T03:00:00Z"),
+		date("2025-03-07T23:00:00Z"),
+	}
+	result := policy.Simulate(date("2025-03-10T12:00:00Z"), backups)
+	assertTimes(t, "kept", result.Kept, []time.Time{
+		date("2025-03-07T23:00:00Z"),
+		date("2025-03-08T01:00:00Z"),
+		date("2025-03-10T10:00:00Z"),
+		date("2025-03-10T11:00:00Z"),
+	})
+	assertTimes(t, "evicted", result.Evicted, []time.Time{
+		date("2025-03-01T00:00:00Z"),
+		date("2025-03-08T03:00:00Z"),
+		date("2025-03-08T05:00:00Z"),
+	})
+
+	// the oldest backup of an interval stays kept as backups get older
+	later := policy.Simulate(date("2025-03-11T12:00:00Z"), result.Kept)
+	assertTimes(t, "kept later", later.Kept, result.Kept[:3])
+	assertTimes(t, "evicted later", later.Evicted, result.Kept[3:])
+}
+
+func TestParseGranular(t *testing.T) {
+	tests := map[string]time.Duration{
+		"3600": time.Hour,
+		"0":    0,
+		"7d":   7 * 24 * time.Hour,
+	}
+	for spec, expected := range tests {
+		if age, err := ParseGranular(spec); err != nil || age != expected {
+			t.Errorf("ParseGranular(%q) = %s, %v", spec, age, err)
+		}
+	}
+	for _, spec := range []string{"-1", "soon", ""} {
+		if _, err := ParseGranular(spec); err == nil {
+			t.Errorf("ParseGranular(%q) expected error", spec)
+		}
+	}
+}
+
+func TestValidateArchive(t *testing.T) {
+	for _, spec := range []string{"7d", "10Gi", "500M"} {
+		if err := ValidateArchive(spec); err != nil {
+			t.Errorf("ValidateArchive(%q): %v", spec, err)
+		}
+	}
+	if err := ValidateArchive("soon"); err == nil {
+		t.Errorf("ValidateArchive(\"soon\") expected error")
+	}
+}
@@ -0,0 +1,79 @@
+// Copyright 2024-2025 NetCracker Technology Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package eviction
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseQuota(t *testing.T) {
+	tests := []struct {
+		quota       string
+		percent     int
+		storageSize string
+		expected    int64
+		err         bool
+	}{
+		{quota: "1Gi", expected: 1 << 30},
+		{percent: 50, storageSize: "10Gi", expected: 5 << 30},
+		{quota: "8Gi", percent: 50, storageSize: "10Gi", expected: 5 << 30},
+		{quota: "1Gi", percent: 50, storageSize: "10Gi", expected: 1 << 30},
+		{percent: 50, err: true},
+		{percent: 101, storageSize: "10Gi", err: true},
+		{quota: "lots", err: true},
+		{quota: "0", err: true},
+		{err: true},
+	}
+	for _, test := range tests {
+		quota, err := ParseQuota(test.quota, test.percent, test.storageSize)
+		if (err != nil) != test.err || quota != test.expected {
+			t.Errorf("ParseQuota(%q, %d, %q) = %d, %v", test.quota, test.percent, test.storageSize, quota, err)
+		}
+	}
+}
+
+func TestSizeLimitApply(t *testing.T) {
+	now := time.Now()
+	backups := []time.Time{now.Add(-4 * time.Hour), now.Add(-3 * time.Hour), now.Add(-2 * time.Hour), now.Add(-time.Hour)}
+	sizes := map[int64]int64{}
+	for _, backup := range backups {
+		sizes[backup.UnixNano()] = 100
+	}
+	// equal instants without monotonic reading and in another location must match sizes
+	kept := []time.Time{backups[3].Round(0).UTC(), backups[1], backups[2]}
+	result := SizeLimit{Quota: 150, MinKept: 1}.Apply(Result{Kept: kept, Evicted: []time.Time{backups[0]}}, sizes)
+	assertTimes(t, "kept", result.Kept, backups[3:])
+	assertTimes(t, "evicted", result.Evicted, backups[:3])
+
+	result = SizeLimit{Quota: 10, MinKept: 2}.Apply(Result{Kept: backups}, sizes)
+	assertTimes(t, "kept", result.Kept, backups[2:])
+	assertTimes(t, "evicted", result.Evicted, backups[:2])
+
+	result = SizeLimit{Quota: 1000, MinKept: 1}.Apply(Result{Kept: backups}, sizes)
+	assertTimes(t, "kept", result.Kept, backups)
+}
+
+func assertTimes(t *testing.T, name string, actual []time.Time, expected []time.Time) {
+	t.Helper()
+	if len(actual) != len(expected) {
+		t.Fatalf("%s: got %v, expected %v", name, actual, expected)
+	}
+	for i := range actual {
+		if !actual[i].Equal(expected[i]) {
+			t.Errorf("%s[%d]: got %s, expected %s", name, i, actual[i], expected[i])
+		}
+	}
+}
@@ -0,0 +1,176 @@
+// Copyright 2024-2025 NetCracker Technology Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reconciler
+
+import (
+	"encoding/base64"
+	"fmt"
+
+	types "github.com/Netcracker/pgskipper-operator-core/api/v1"
+	"github.com/Netcracker/pgskipper-operator-core/pkg/util"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	BackupDaemonAuthSecret = "postgres-backup-daemon-auth"
+	BackupDaemonAuthBasic  = "basic"
+	BackupDaemonAuthToken  = "token"
+
+	backupDaemonAuthUser = "backup-daemon"
+	authTokenSize        = 32
+)
+
+// BackupDaemonCredentials are used by other components to call backup daemon endpoints.
+type BackupDaemonCredentials struct {
+	Type     string
+	Username string
+	Password string
+	Token    string
+}
+
+// AuthorizationHeader returns value of the Authorization header for daemon requests.
+func (c *BackupDaemonCredentials) AuthorizationHeader() string {
+	if c.Type == BackupDaemonAuthToken {
+		return fmt.Sprintf("Bearer %s", c.Token)
+	}
+	auth := base64.StdEncoding.EncodeToString([]byte(fmt.Sprintf("%s:%s", c.Username, c.Password)))
+	return fmt.Sprintf("Basic %s", auth)
+}
+
+func GetBackupDaemonAuthSecretName(instance string, auth *types.BackupDaemonAuth) string {
+	if auth != nil && auth.SecretName != "" {
+		return auth.SecretName
+	}
+	return util.InstanceName(BackupDaemonAuthSecret, instance)
+}
+
+func getBackupDaemonAuthType(auth *types.BackupDaemonAuth) string {
+	if auth.Type == "" {
+		return BackupDaemonAuthBasic
+	}
+	return auth.Type
+}
+
+// NewBackupDaemonAuthSecret generates credentials for both auth types, so switching the type
+// does not require a new Secret. It should be created only if absent, as every call
+// generates new credentials. Nil is returned for a user supplied Secret, which is never
+// generated.
+func NewBackupDaemonAuthSecret(scope Scope, auth *types.BackupDaemonAuth) *corev1.Secret {
+	if auth != nil && auth.SecretName != "" {
+		return nil
+	}
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      GetBackupDaemonAuthSecretName(scope.Instance, auth),
+			Namespace: scope.GetNamespace(),
+			Labels:    GetBackupDaemonLabels(scope.Instance),
+		},
+		Type: corev1.SecretTypeOpaque,
+		StringData: map[string]string{
+			"username": backupDaemonAuthUser,
+			"password": util.RandomToken(authTokenSize),
+			"token":    util.RandomToken(authTokenSize),
+		},
+	}
+}
+
+// GetBackupDaemonCredentials reads daemon credentials from the auth Secret.
+// Nil credentials are returned when auth is disabled.
+func GetBackupDaemonCredentials(auth *types.BackupDaemonAuth, secret *corev1.Secret) (*BackupDaemonCredentials, error) {
+	if auth == nil || !auth.Enabled {
+		return nil, nil
+	}
+	if secret == nil {
+		return nil, fmt.Errorf("backup daemon auth is enabled, but secret is not found")
+	}
+	credentials := &BackupDaemonCredentials{Type: getBackupDaemonAuthType(auth)}
+	var required []string
+	switch credentials.Type {
+	case BackupDaemonAuthBasic:
+		credentials.Username = getSecretValue(secret, "username")
+		credentials.Password = getSecretValue(secret, "password")
+		required = []string{credentials.Username, credentials.Password}
+	case BackupDaemonAuthToken:
+		credentials.Token = getSecretValue(secret, "token")
+		required = []string{credentials.Token}
+	default:
+		return nil, fmt.Errorf("unknown backup daemon auth type %s", credentials.Type)
+	}
+	for _, value := range required {
+		if value == "" {
+			return nil, fmt.Errorf("secret %s has no %s credentials", secret.Name, credentials.Type)
+		}
+	}
+	return credentials, nil
+}
+
+// GetBackupDaemonAuthEnvs returns env variables with credentials from the auth Secret,
+// for components which call the daemon.
+func GetBackupDaemonAuthEnvs(instance string, auth *types.BackupDaemonAuth) []corev1.EnvVar {
+	if auth == nil || !auth.Enabled {
+		return []corev1.EnvVar{}
+	}
+	secretName := GetBackupDaemonAuthSecretName(instance, auth)
+	if getBackupDaemonAuthType(auth) == BackupDaemonAuthToken {
+		return []corev1.EnvVar{
+			getSecretEnv("BACKUP_DAEMON_AUTH_TOKEN", secretName, "token"),
+		}
+	}
+	return []corev1.EnvVar{
+		getSecretEnv("BACKUP_DAEMON_AUTH_USERNAME", secretName, "username"),
+		getSecretEnv("BACKUP_DAEMON_AUTH_PASSWORD", secretName, "password"),
+	}
+}
+
+func getBackupDaemonAuthEnvs(instance string, auth *types.BackupDaemonAuth) []corev1.EnvVar {
+	if auth == nil || !auth.Enabled {
+		return []corev1.EnvVar{
+			{
+				Name:  "AUTH",
+				Value: "False",
+			},
+		}
+	}
+	return append([]corev1.EnvVar{
+		{
+			Name:  "AUTH",
+			Value: "True",
+		},
+		{
+			Name:  "AUTH_TYPE",
+			Value: getBackupDaemonAuthType(auth),
+		},
+	}, GetBackupDaemonAuthEnvs(instance, auth)...)
+}
+
+func getSecretEnv(name string, secretName string, key string) corev1.EnvVar {
+	return corev1.EnvVar{
+		Name: name,
+		ValueFrom: &corev1.EnvVarSource{
+			SecretKeyRef: &corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: secretName},
+				Key:                  key,
+			},
+		},
+	}
+}
+
+func getSecretValue(secret *corev1.Secret, key string) string {
+	if value, ok := secret.Data[key]; ok {
+		return string(value)
+	}
+	return secret.StringData[key]
+}
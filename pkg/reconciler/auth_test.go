@@ -0,0 +1,66 @@
+// Copyright 2024-2025 NetCracker Technology Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reconciler
+
+import (
+	"testing"
+
+	types "github.com/Netcracker/pgskipper-operator-core/api/v1"
+)
+
+func TestNewBackupDaemonAuthSecret(t *testing.T) {
+	tests := map[string]struct {
+		scope Scope
+		auth  *types.BackupDaemonAuth
+		name  string
+	}{
+		"generated": {
+			scope: Scope{Namespace: "pg"},
+			auth:  &types.BackupDaemonAuth{Enabled: true},
+			name:  BackupDaemonAuthSecret,
+		},
+		"generated for instance": {
+			scope: Scope{Namespace: "pg", Instance: "eu"},
+			auth:  &types.BackupDaemonAuth{Enabled: true, Type: BackupDaemonAuthToken},
+			name:  BackupDaemonAuthSecret + "-eu",
+		},
+		"user supplied": {
+			scope: Scope{Namespace: "pg"},
+			auth:  &types.BackupDaemonAuth{Enabled: true, SecretName: "my-credentials"},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			secret := NewBackupDaemonAuthSecret(tt.scope, tt.auth)
+			if tt.name == "" {
+				if secret != nil {
+					t.Fatalf("secret %s is generated for user supplied secret", secret.Name)
+				}
+				return
+			}
+			if secret == nil {
+				t.Fatal("secret is not generated")
+			}
+			if secret.Name != tt.name || secret.Namespace != "pg" {
+				t.Errorf("secret is %s/%s, want pg/%s", secret.Namespace, secret.Name, tt.name)
+			}
+			for _, key := range []string{"username", "password", "token"} {
+				if secret.StringData[key] == "" {
+					t.Errorf("secret has no %s", key)
+				}
+			}
+		})
+	}
+}
@@ -15,12 +15,14 @@
 package reconciler
 
 import (
-	"fmt"
+	"encoding/json"
 	"strconv"
 
 	types "github.com/Netcracker/pgskipper-operator-core/api/v1"
+	"github.com/Netcracker/pgskipper-operator-core/pkg/eviction"
 	"github.com/Netcracker/pgskipper-operator-core/pkg/storage"
 	"github.com/Netcracker/pgskipper-operator-core/pkg/util"
+	"go.uber.org/zap"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -32,13 +34,55 @@ var (
 )
 
 const (
-	BackupDaemon = "postgres-backup-daemon"
+	DefaultEndpointsWorkers     = 2
+	DefaultPgPort               = 5432
+	DefaultCompressionAlgorithm = "gzip"
+	DefaultMinFullBackups       = 1
 )
 
-func NewBackupDaemonDeployment(backupDaemon *types.BackupDaemon, pgClusterName string, serviceAccountName string) *appsv1.Deployment {
-	// func NewBackupDaemonDeployment(backupDaemon	*cr.Spec.BackupDaemon *
-	// backupDaemon := &cr.Spec.BackupDaemon
-	nodes := backupDaemon.Storage.Nodes
+const (
+	BackupDaemon                   = "postgres-backup-daemon"
+	BackupDaemonPvc                = "postgres-backup-pvc"
+	ExternalBackupDaemonPvc        = "external-postgres-backup-pvc"
+	fullBackupsCollectorConfig     = "postgres-backup-daemon.collector-config"
+	granularBackupsCollectorConfig = "postgres-granular-backup-daemon.collector-config"
+)
+
+func GetBackupDaemonName(instance string) string {
+	return util.InstanceName(BackupDaemon, instance)
+}
+
+func GetBackupDaemonLabels(instance string) map[string]string {
+	if instance == "" {
+		// a copy, as callers put labels on objects and may change them
+		return util.Merge(BackupDaemonLabels)
+	}
+	return instanceLabels(util.Merge(BackupDaemonLabels, map[string]string{"name": GetBackupDaemonName(instance)}), instance)
+}
+
+func GetBackupDaemonPvcName(instance string) string {
+	return util.InstanceName(BackupDaemonPvc, instance)
+}
+
+func GetExternalBackupDaemonPvcName(instance string) string {
+	return util.InstanceName(ExternalBackupDaemonPvc, instance)
+}
+
+func NewBackupDaemonDeployment(backupDaemon *types.BackupDaemon, pgClusterName string, serviceAccountName string, opts ...DeploymentOption) *appsv1.Deployment {
+	return NewBackupDaemonDeploymentForInstance("", backupDaemon, pgClusterName, serviceAccountName, opts...)
+}
+
+// NewBackupDaemonDeploymentForInstance builds backup daemon deployment with object names,
+// labels and claims scoped to instance, so several clusters can share a namespace.
+func NewBackupDaemonDeploymentForInstance(instance string, backupDaemon *types.BackupDaemon, pgClusterName string, serviceAccountName string, opts ...DeploymentOption) *appsv1.Deployment {
+	return NewBackupDaemonDeploymentInScope(Scope{Instance: instance}, backupDaemon, pgClusterName, serviceAccountName, opts...)
+}
+
+// NewBackupDaemonDeploymentInScope builds backup daemon deployment in the namespace and for the instance of scope.
+// Options are applied to the deployment after it is built.
+func NewBackupDaemonDeploymentInScope(scope Scope, backupDaemon *types.BackupDaemon, pgClusterName string, serviceAccountName string, opts ...DeploymentOption) *appsv1.Deployment {
+	instance := scope.Instance
+	labels := GetBackupDaemonLabels(instance)
 	pgHost := backupDaemon.PgHost
 	sslMode := "prefer"
 	if backupDaemon.SslMode != "" {
@@ -46,20 +90,20 @@ func NewBackupDaemonDeployment(backupDaemon *types.BackupDaemon, pgClusterName s
 	}
 	deployment := &appsv1.Deployment{
 		ObjectMeta: metav1.ObjectMeta{
-			Name:      BackupDaemon,
-			Namespace: util.GetNameSpace(),
-			Labels:    util.Merge(BackupDaemonLabels, backupDaemon.PodLabels),
+			Name:      GetBackupDaemonName(instance),
+			Namespace: scope.GetNamespace(),
+			Labels:    util.Merge(labels, backupDaemon.PodLabels),
 		},
 		Spec: appsv1.DeploymentSpec{
 			Strategy: appsv1.DeploymentStrategy{
 				Type: appsv1.RecreateDeploymentStrategyType,
 			},
 			Selector: &metav1.LabelSelector{
-				MatchLabels: util.Merge(BackupDaemonLabels, backupDaemon.PodLabels),
+				MatchLabels: util.Merge(labels, backupDaemon.PodLabels),
 			},
 			Template: corev1.PodTemplateSpec{
 				ObjectMeta: metav1.ObjectMeta{
-					Labels: util.Merge(BackupDaemonLabels, backupDaemon.PodLabels),
+					Labels: util.Merge(labels, backupDaemon.PodLabels),
 				},
 				Spec: corev1.PodSpec{
 					Volumes: []corev1.Volume{
@@ -67,14 +111,14 @@ func NewBackupDaemonDeployment(backupDaemon *types.BackupDaemon, pgClusterName s
 							Name: "backup-data",
 							VolumeSource: corev1.VolumeSource{
 								PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
-									ClaimName: "postgres-backup-pvc",
+									ClaimName: GetBackupDaemonPvcName(instance),
 									ReadOnly:  false,
 								},
 							},
 						},
 					},
 					ServiceAccountName: serviceAccountName,
-					Affinity:           &backupDaemon.Affinity,
+					Affinity:           getBackupDaemonAffinity(backupDaemon),
 					InitContainers:     []corev1.Container{},
 					Containers: []corev1.Container{
 						{
@@ -82,12 +126,12 @@ func NewBackupDaemonDeployment(backupDaemon *types.BackupDaemon, pgClusterName s
 							Image:   backupDaemon.DockerImage,
 							Command: []string{},
 							Args:    []string{},
-							Env: []corev1.EnvVar{
+							Env: append([]corev1.EnvVar{
 								{
 									Name: "POSTGRES_PASSWORD",
 									ValueFrom: &corev1.EnvVarSource{
 										SecretKeyRef: &corev1.SecretKeySelector{
-											LocalObjectReference: corev1.LocalObjectReference{Name: GetRootSecretName(pgClusterName)},
+											LocalObjectReference: corev1.LocalObjectReference{Name: GetRootSecretNameForInstance(pgClusterName, instance)},
 											Key:                  "password",
 										},
 									},
@@ -96,7 +140,7 @@ func NewBackupDaemonDeployment(backupDaemon *types.BackupDaemon, pgClusterName s
 									Name: "POSTGRES_USER",
 									ValueFrom: &corev1.EnvVarSource{
 										SecretKeyRef: &corev1.SecretKeySelector{
-											LocalObjectReference: corev1.LocalObjectReference{Name: GetRootSecretName(pgClusterName)},
+											LocalObjectReference: corev1.LocalObjectReference{Name: GetRootSecretNameForInstance(pgClusterName, instance)},
 											Key:                  "username",
 										},
 									},
@@ -105,7 +149,7 @@ func NewBackupDaemonDeployment(backupDaemon *types.BackupDaemon, pgClusterName s
 									Name: "PGPASSWORD",
 									ValueFrom: &corev1.EnvVarSource{
 										SecretKeyRef: &corev1.SecretKeySelector{
-											LocalObjectReference: corev1.LocalObjectReference{Name: GetReplSecretName(pgClusterName)},
+											LocalObjectReference: corev1.LocalObjectReference{Name: GetReplSecretNameForInstance(pgClusterName, instance)},
 											Key:                  "password",
 										},
 									},
@@ -116,15 +160,15 @@ func NewBackupDaemonDeployment(backupDaemon *types.BackupDaemon, pgClusterName s
 								},
 								{
 									Name:  "PUBLIC_ENDPOINTS_WORKERS_NUMBER",
-									Value: "2",
+									Value: strconv.Itoa(withDefault(backupDaemon.PublicEndpointsWorkers, DefaultEndpointsWorkers)),
 								},
 								{
 									Name:  "PRIVATE_ENDPOINTS_WORKERS_NUMBER",
-									Value: "2",
+									Value: strconv.Itoa(withDefault(backupDaemon.PrivateEndpointsWorkers, DefaultEndpointsWorkers)),
 								},
 								{
 									Name:  "ARCHIVE_ENDPOINTS_WORKERS_NUMBER",
-									Value: "2",
+									Value: strconv.Itoa(withDefault(backupDaemon.ArchiveEndpointsWorkers, DefaultEndpointsWorkers)),
 								},
 								{
 									Name:  "GRANULAR_EVICTION",
@@ -144,7 +188,11 @@ func NewBackupDaemonDeployment(backupDaemon *types.BackupDaemon, pgClusterName s
 								},
 								{
 									Name:  "EXCLUDED_EXTENSIONS",
-									Value: backupDaemon.ExcludedExtensions,
+									Value: getExcludedExtensions(backupDaemon),
+								},
+								{
+									Name:  "COMPRESSION_ALGORITHM",
+									Value: getCompressionAlgorithm(backupDaemon),
 								},
 								{
 									Name:  "COMPRESSION_LEVEL",
@@ -178,17 +226,13 @@ func NewBackupDaemonDeployment(backupDaemon *types.BackupDaemon, pgClusterName s
 									Name:  "EVICTION_POLICY_BINARY",
 									Value: backupDaemon.EvictionBinaryPolicy,
 								},
-								{
-									Name:  "AUTH",
-									Value: "False",
-								},
 								{
 									Name:  "POSTGRES_HOST",
 									Value: pgHost,
 								},
 								{
 									Name:  "POSTGRES_PORT",
-									Value: "5432",
+									Value: strconv.Itoa(withDefault(backupDaemon.PgPort, DefaultPgPort)),
 								},
 								{
 									Name:  "STORAGE_TYPE",
@@ -218,7 +262,7 @@ func NewBackupDaemonDeployment(backupDaemon *types.BackupDaemon, pgClusterName s
 										},
 									},
 								},
-							},
+							}, getBackupDaemonAuthEnvs(instance, backupDaemon.Auth)...),
 							Ports: []corev1.ContainerPort{
 								{Name: "web", ContainerPort: 8080},
 								{Name: "backups", ContainerPort: 8081},
@@ -231,33 +275,10 @@ func NewBackupDaemonDeployment(backupDaemon *types.BackupDaemon, pgClusterName s
 									Name:      "backup-data",
 								},
 							},
-							LivenessProbe: &corev1.Probe{
-								ProbeHandler: corev1.ProbeHandler{
-									HTTPGet: &corev1.HTTPGetAction{
-										Path: "/v2/health",
-										Port: intstr.FromInt(8080),
-									},
-								},
-								InitialDelaySeconds: 20,
-								PeriodSeconds:       10,
-								FailureThreshold:    30,
-								TimeoutSeconds:      5,
-								SuccessThreshold:    1,
-							},
-							ReadinessProbe: &corev1.Probe{
-								ProbeHandler: corev1.ProbeHandler{
-									HTTPGet: &corev1.HTTPGetAction{
-										Path: "/v2/health",
-										Port: intstr.FromInt(8080),
-									},
-								},
-								InitialDelaySeconds: 20,
-								PeriodSeconds:       10,
-								FailureThreshold:    30,
-								TimeoutSeconds:      5,
-								SuccessThreshold:    1,
-							},
-							Resources: *backupDaemon.Resources,
+							StartupProbe:   getBackupDaemonStartupProbe(backupDaemon),
+							LivenessProbe:  getBackupDaemonLivenessProbe(backupDaemon),
+							ReadinessProbe: getBackupDaemonReadinessProbe(backupDaemon),
+							Resources:      *backupDaemon.Resources,
 						},
 					},
 					SecurityContext: &backupDaemon.SecurityContext,
@@ -265,16 +286,12 @@ func NewBackupDaemonDeployment(backupDaemon *types.BackupDaemon, pgClusterName s
 			},
 		},
 	}
-	if nodes != nil {
-		deployment.Spec.Template.Spec.NodeSelector = map[string]string{
-			"kubernetes.io/hostname": nodes[0],
-		}
-	}
+	setScheduling(&deployment.Spec.Template.Spec, backupDaemon.NodeSelector, backupDaemon.Tolerations,
+		backupDaemon.TopologySpreadConstraints, backupDaemon.RuntimeClassName)
 	if backupDaemon.PriorityClassName != "" {
 		deployment.Spec.Template.Spec.PriorityClassName = backupDaemon.PriorityClassName
 	}
-	storageType := backupDaemon.Storage.Type
-	if storageType == "ephemeral" || storageType == "s3" {
+	if !storage.IsPersistent(backupDaemon.Storage.Type) {
 		deployment.Spec.Template.Spec.Volumes = []corev1.Volume{
 			{
 				Name: "backup-data",
@@ -289,7 +306,7 @@ func NewBackupDaemonDeployment(backupDaemon *types.BackupDaemon, pgClusterName s
 				Name: "backup-data",
 				VolumeSource: corev1.VolumeSource{
 					PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
-						ClaimName: fmt.Sprintf("postgres-backup-pvc"),
+						ClaimName: GetBackupDaemonPvcName(instance),
 						ReadOnly:  false,
 					},
 				},
@@ -298,19 +315,153 @@ func NewBackupDaemonDeployment(backupDaemon *types.BackupDaemon, pgClusterName s
 	}
 	if backupDaemon.ExternalPv != nil {
 		deployment.Spec.Template.Spec.Volumes =
-			append(deployment.Spec.Template.Spec.Volumes, getExternalBackupVolume())
+			append(deployment.Spec.Template.Spec.Volumes, getExternalBackupVolume(instance))
 		deployment.Spec.Template.Spec.Containers[0].VolumeMounts =
 			append(deployment.Spec.Template.Spec.Containers[0].VolumeMounts, getExternalBackupVolumeMount())
 	}
+	if isTlsEnabled(backupDaemon.Tls) {
+		deployment.Spec.Template.Spec.Volumes =
+			append(deployment.Spec.Template.Spec.Volumes, getBackupDaemonTlsVolume(instance, backupDaemon.Tls))
+		deployment.Spec.Template.Spec.Containers[0].VolumeMounts =
+			append(deployment.Spec.Template.Spec.Containers[0].VolumeMounts, getBackupDaemonTlsVolumeMount())
+		deployment.Spec.Template.Spec.Containers[0].Env =
+			append(deployment.Spec.Template.Spec.Containers[0].Env, getBackupDaemonTlsEnvs(backupDaemon.Tls)...)
+	}
+	setPgSsl(deployment, backupDaemon.Ssl)
+	setS3Settings(deployment, backupDaemon.S3Storage)
+	setGcsSettings(deployment, backupDaemon.GcsStorage)
+	setAzureSettings(deployment, backupDaemon.AzureStorage)
+	setCopyTargets(deployment, instance, backupDaemon.CopyTargets)
+	setBackupHooks(deployment, instance, backupDaemon.Hooks)
+	setNotifications(deployment, instance, backupDaemon.Notifications)
+	setThrottling(deployment, backupDaemon.Throttling)
+	deployment.Spec.Template.Spec.Containers[0].Env =
+		append(deployment.Spec.Template.Spec.Containers[0].Env, getGranularSchedulesEnvs(backupDaemon)...)
+	deployment.Spec.Template.Spec.Containers[0].Env =
+		append(deployment.Spec.Template.Spec.Containers[0].Env, getGranularDumpEnvs(backupDaemon.GranularDumpOptions)...)
+	deployment.Spec.Template.Spec.Containers[0].Env =
+		append(deployment.Spec.Template.Spec.Containers[0].Env, getBackupWindowsEnvs(backupDaemon.BackupWindows)...)
+	deployment.Spec.Template.Spec.Containers[0].Env =
+		append(deployment.Spec.Template.Spec.Containers[0].Env, getSizeRetentionEnvs(backupDaemon)...)
+	if backupDaemon.Timezone != "" {
+		// the daemon evaluates schedules in its local time
+		deployment.Spec.Template.Spec.Containers[0].Env =
+			append(deployment.Spec.Template.Spec.Containers[0].Env, corev1.EnvVar{Name: "TZ", Value: backupDaemon.Timezone})
+	}
+	deployment = applyDeploymentOptions(deployment, opts)
+	setNotifierPullPolicy(deployment)
 	return deployment
 }
 
-func getExternalBackupVolume() corev1.Volume {
+// getCompressionAlgorithm defaults to gzip the daemon used before the algorithm was configurable.
+func getCompressionAlgorithm(backupDaemon *types.BackupDaemon) string {
+	if backupDaemon.CompressionAlgorithm == "" {
+		return DefaultCompressionAlgorithm
+	}
+	return backupDaemon.CompressionAlgorithm
+}
+
+func getBackupWindowsEnvs(backupWindows *types.BackupWindows) []corev1.EnvVar {
+	if backupWindows == nil {
+		return []corev1.EnvVar{}
+	}
+	windows, err := json.Marshal(backupWindows)
+	if err != nil {
+		logger.Error("Failed to render backup windows", zap.Error(err))
+		return []corev1.EnvVar{}
+	}
+	return []corev1.EnvVar{
+		{
+			Name:  "BACKUP_WINDOWS",
+			Value: string(windows),
+		},
+	}
+}
+
+// getSizeRetentionEnvs passes the resolved quota in bytes, so the daemon does not need to know Storage.Size.
+func getSizeRetentionEnvs(backupDaemon *types.BackupDaemon) []corev1.EnvVar {
+	retention := backupDaemon.SizeRetention
+	if retention == nil {
+		return []corev1.EnvVar{}
+	}
+	quota, err := eviction.ParseQuota(retention.Quota, retention.MaxPercent, backupDaemon.Storage.Size)
+	if err != nil {
+		logger.Error("Failed to resolve backup storage quota", zap.Error(err))
+		return []corev1.EnvVar{}
+	}
+	return []corev1.EnvVar{
+		{
+			Name:  "STORAGE_QUOTA_BYTES",
+			Value: strconv.FormatInt(quota, 10),
+		},
+		{
+			Name:  "MIN_FULL_BACKUPS",
+			Value: strconv.Itoa(withDefault(retention.MinFullBackups, DefaultMinFullBackups)),
+		},
+	}
+}
+
+// getBackupDaemonAffinity returns a copy of the configured affinity pinned to Storage.Nodes.
+// Backup daemon uses the first claim, so it runs on the first node unless failover is enabled.
+func getBackupDaemonAffinity(backupDaemon *types.BackupDaemon) *corev1.Affinity {
+	affinity := backupDaemon.Affinity.DeepCopy()
+	storage.PinToNodes(affinity, &backupDaemon.Storage, 1)
+	return affinity
+}
+
+// getBackupDaemonStartupProbe gives the daemon the same time to start as the legacy
+// probes did, liveness and readiness probes are not run until it succeeds.
+func getBackupDaemonStartupProbe(backupDaemon *types.BackupDaemon) *corev1.Probe {
+	return applyProbeSettings(&corev1.Probe{
+		ProbeHandler:        getBackupDaemonHealthHandler(backupDaemon),
+		InitialDelaySeconds: 20,
+		PeriodSeconds:       10,
+		FailureThreshold:    30,
+		TimeoutSeconds:      5,
+		SuccessThreshold:    1,
+	}, backupDaemon.StartupProbe)
+}
+
+// getBackupDaemonLivenessProbe restarts a daemon which has not answered HTTP for as long as
+// the legacy probe tolerated, about 5 minutes. Readiness takes a slow daemon out of service
+// within half a minute, so a daemon busy with a long backup stops getting requests long before
+// it is considered hung.
+func getBackupDaemonLivenessProbe(backupDaemon *types.BackupDaemon) *corev1.Probe {
+	return applyProbeSettings(&corev1.Probe{
+		ProbeHandler:     getBackupDaemonHealthHandler(backupDaemon),
+		PeriodSeconds:    10,
+		FailureThreshold: 30,
+		TimeoutSeconds:   10,
+		SuccessThreshold: 1,
+	}, backupDaemon.LivenessProbe)
+}
+
+func getBackupDaemonReadinessProbe(backupDaemon *types.BackupDaemon) *corev1.Probe {
+	return applyProbeSettings(&corev1.Probe{
+		ProbeHandler:     getBackupDaemonHealthHandler(backupDaemon),
+		PeriodSeconds:    10,
+		FailureThreshold: 3,
+		TimeoutSeconds:   5,
+		SuccessThreshold: 1,
+	}, backupDaemon.ReadinessProbe)
+}
+
+func getBackupDaemonHealthHandler(backupDaemon *types.BackupDaemon) corev1.ProbeHandler {
+	return corev1.ProbeHandler{
+		HTTPGet: &corev1.HTTPGetAction{
+			Path:   "/v2/health",
+			Port:   intstr.FromInt(8080),
+			Scheme: getBackupDaemonScheme(backupDaemon.Tls),
+		},
+	}
+}
+
+func getExternalBackupVolume(instance string) corev1.Volume {
 	return corev1.Volume{
 		Name: "external-backup-data",
 		VolumeSource: corev1.VolumeSource{
 			PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
-				ClaimName: "external-postgres-backup-pvc",
+				ClaimName: GetExternalBackupDaemonPvcName(instance),
 				ReadOnly:  false,
 			},
 		},
@@ -325,11 +476,29 @@ func getExternalBackupVolumeMount() corev1.VolumeMount {
 }
 
 func ConfigMapForFullBackupsMonitoring(telegrafJsonKey string) *corev1.ConfigMap {
-	return storage.GetConfigMapByName("postgres-backup-daemon.collector-config", telegrafJsonKey)
+	return ConfigMapForFullBackupsMonitoringForInstance("", telegrafJsonKey)
+}
+
+func ConfigMapForFullBackupsMonitoringForInstance(instance string, telegrafJsonKey string) *corev1.ConfigMap {
+	return ConfigMapForFullBackupsMonitoringInScope(Scope{Instance: instance}, telegrafJsonKey)
+}
+
+func ConfigMapForFullBackupsMonitoringInScope(scope Scope, telegrafJsonKey string) *corev1.ConfigMap {
+	return storage.GetConfigMapFromFile(util.InstanceName(fullBackupsCollectorConfig, scope.Instance), scope.Namespace,
+		fullBackupsCollectorConfig, telegrafJsonKey)
 }
 
 func ConfigMapForGranularBackupsMonitoring(telegrafJsonKey string) *corev1.ConfigMap {
-	return storage.GetConfigMapByName("postgres-granular-backup-daemon.collector-config", telegrafJsonKey)
+	return ConfigMapForGranularBackupsMonitoringForInstance("", telegrafJsonKey)
+}
+
+func ConfigMapForGranularBackupsMonitoringForInstance(instance string, telegrafJsonKey string) *corev1.ConfigMap {
+	return ConfigMapForGranularBackupsMonitoringInScope(Scope{Instance: instance}, telegrafJsonKey)
+}
+
+func ConfigMapForGranularBackupsMonitoringInScope(scope Scope, telegrafJsonKey string) *corev1.ConfigMap {
+	return storage.GetConfigMapFromFile(util.InstanceName(granularBackupsCollectorConfig, scope.Instance), scope.Namespace,
+		granularBackupsCollectorConfig, telegrafJsonKey)
 }
 
 func GetPortsForBackupService() []corev1.ServicePort {
@@ -347,6 +516,7 @@ func GetRootSecretName(pgClusterName string) string {
 		return "postgres-credentials"
 	}
 }
+
 func GetReplSecretName(pgClusterName string) string {
 	if pgClusterName == "gpdb" {
 		return "gpdb-pg-repl-credentials"
@@ -354,3 +524,13 @@ func GetReplSecretName(pgClusterName string) string {
 		return "replicator-credentials"
 	}
 }
+
+// GetRootSecretNameForInstance returns root credentials Secret of the Postgres cluster of instance.
+func GetRootSecretNameForInstance(pgClusterName string, instance string) string {
+	return util.InstanceName(GetRootSecretName(pgClusterName), instance)
+}
+
+// GetReplSecretNameForInstance returns replication credentials Secret of the Postgres cluster of instance.
+func GetReplSecretNameForInstance(pgClusterName string, instance string) string {
+	return util.InstanceName(GetReplSecretName(pgClusterName), instance)
+}
@@ -0,0 +1,86 @@
+// Copyright 2024-2025 NetCracker Technology Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reconciler
+
+import (
+	types "github.com/Netcracker/pgskipper-operator-core/api/v1"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	gcsCredentialsDir        = "/gcs/"
+	gcsCredentialsFile       = "credentials.json"
+	gcsCredentialsVolumeName = "gcs-credentials"
+)
+
+func setGcsSettings(deployment *appsv1.Deployment, gcsStorage *types.GcsStorage) {
+	if gcsStorage == nil {
+		return
+	}
+	container := mainContainer(deployment)
+	container.Env = append(container.Env,
+		corev1.EnvVar{Name: "GCS_BUCKET", Value: gcsStorage.Bucket},
+		corev1.EnvVar{Name: "GCS_PREFIX", Value: gcsStorage.Prefix},
+	)
+	if gcsStorage.Credentials == nil {
+		return
+	}
+	deployment.Spec.Template.Spec.Volumes = append(deployment.Spec.Template.Spec.Volumes, corev1.Volume{
+		Name: gcsCredentialsVolumeName,
+		VolumeSource: corev1.VolumeSource{
+			Secret: &corev1.SecretVolumeSource{
+				SecretName: gcsStorage.Credentials.Name,
+				Items:      []corev1.KeyToPath{{Key: gcsStorage.Credentials.Key, Path: gcsCredentialsFile}},
+			},
+		},
+	})
+	container.VolumeMounts = append(container.VolumeMounts, corev1.VolumeMount{
+		MountPath: gcsCredentialsDir,
+		Name:      gcsCredentialsVolumeName,
+		ReadOnly:  true,
+	})
+	container.Env = append(container.Env, corev1.EnvVar{
+		Name:  "GOOGLE_APPLICATION_CREDENTIALS",
+		Value: gcsCredentialsDir + gcsCredentialsFile,
+	})
+}
+
+func setAzureSettings(deployment *appsv1.Deployment, azureStorage *types.AzureStorage) {
+	if azureStorage == nil {
+		return
+	}
+	container := mainContainer(deployment)
+	container.Env = append(container.Env,
+		corev1.EnvVar{Name: "AZURE_STORAGE_ACCOUNT", Value: azureStorage.AccountName},
+		corev1.EnvVar{Name: "AZURE_STORAGE_CONTAINER", Value: azureStorage.Container},
+		corev1.EnvVar{Name: "AZURE_STORAGE_PREFIX", Value: azureStorage.Prefix},
+	)
+	if azureStorage.Endpoint != "" {
+		container.Env = append(container.Env, corev1.EnvVar{Name: "AZURE_STORAGE_BLOB_ENDPOINT", Value: azureStorage.Endpoint})
+	}
+	if azureStorage.AccountKey != nil {
+		container.Env = append(container.Env, corev1.EnvVar{
+			Name:      "AZURE_STORAGE_KEY",
+			ValueFrom: &corev1.EnvVarSource{SecretKeyRef: azureStorage.AccountKey},
+		})
+	}
+	if azureStorage.SasToken != nil {
+		container.Env = append(container.Env, corev1.EnvVar{
+			Name:      "AZURE_STORAGE_SAS_TOKEN",
+			ValueFrom: &corev1.EnvVarSource{SecretKeyRef: azureStorage.SasToken},
+		})
+	}
+}
@@ -0,0 +1,317 @@
+// Copyright 2024-2025 NetCracker Technology Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reconciler
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	types "github.com/Netcracker/pgskipper-operator-core/api/v1"
+	"github.com/Netcracker/pgskipper-operator-core/pkg/storage"
+	"github.com/Netcracker/pgskipper-operator-core/pkg/util"
+	"go.uber.org/zap"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	BackupCopyTargetsSecret   = "postgres-backup-daemon-copy-targets"
+	CopyTargetsHashAnnotation = "qubership.org/copy-targets-hash"
+
+	copyTargetsConfigKey       = "copy-targets.json"
+	copyTargetsDir             = "/copy-targets/"
+	copyTargetsVolumeName      = "copy-targets"
+	copyCredentialsDir         = "/copy-targets-credentials/"
+	copyCredentialsVolumeName  = "copy-targets-credentials"
+	copyTargetDataDir          = "/copy/"
+	copyTargetDataVolumePrefix = "copy-target-"
+)
+
+// copyTargetConfig is the copy target as the daemon reads it, Secret references
+// are replaced with paths of the mounted files.
+type copyTargetConfig struct {
+	Name           string             `json:"name"`
+	Type           string             `json:"type"`
+	EvictionPolicy string             `json:"evictionPolicy,omitempty"`
+	S3             *s3TargetConfig    `json:"s3,omitempty"`
+	Gcs            *gcsTargetConfig   `json:"gcs,omitempty"`
+	Azure          *azureTargetConfig `json:"azure,omitempty"`
+	Path           string             `json:"path,omitempty"`
+}
+
+// s3TargetConfig carries the same settings as S3 envs of the primary storage.
+type s3TargetConfig struct {
+	Url                        string `json:"url,omitempty"`
+	AccessKeyId                string `json:"accessKeyId,omitempty"`
+	SecretAccessKey            string `json:"secretAccessKey,omitempty"`
+	Bucket                     string `json:"bucket,omitempty"`
+	Prefix                     string `json:"prefix,omitempty"`
+	Region                     string `json:"region,omitempty"`
+	UntrustedCert              bool   `json:"untrustedCert,omitempty"`
+	CaBundleFile               string `json:"caBundleFile,omitempty"`
+	AddressingStyle            string `json:"addressingStyle,omitempty"`
+	Sse                        string `json:"sse,omitempty"`
+	SseKmsKeyId                string `json:"sseKmsKeyId,omitempty"`
+	StorageClass               string `json:"storageClass,omitempty"`
+	ObjectLockMode             string `json:"objectLockMode,omitempty"`
+	ObjectLockRetentionSeconds int64  `json:"objectLockRetentionSeconds,omitempty"`
+}
+
+type gcsTargetConfig struct {
+	Bucket          string `json:"bucket"`
+	Prefix          string `json:"prefix,omitempty"`
+	CredentialsFile string `json:"credentialsFile,omitempty"`
+}
+
+type azureTargetConfig struct {
+	AccountName    string `json:"accountName"`
+	Container      string `json:"container"`
+	Prefix         string `json:"prefix,omitempty"`
+	Endpoint       string `json:"endpoint,omitempty"`
+	AccountKeyFile string `json:"accountKeyFile,omitempty"`
+	SasTokenFile   string `json:"sasTokenFile,omitempty"`
+}
+
+func GetBackupCopyTargetsSecretName(instance string) string {
+	return util.InstanceName(BackupCopyTargetsSecret, instance)
+}
+
+// NewBackupCopyTargetsSecret renders copy targets config for the daemon. It is a Secret,
+// as S3 targets carry access keys. Nil is returned when there are no copy targets.
+func NewBackupCopyTargetsSecret(scope Scope, backupDaemon *types.BackupDaemon) (*corev1.Secret, error) {
+	if len(backupDaemon.CopyTargets) == 0 {
+		return nil, nil
+	}
+	configs := make([]copyTargetConfig, 0, len(backupDaemon.CopyTargets))
+	for _, target := range backupDaemon.CopyTargets {
+		configs = append(configs, getCopyTargetConfig(target))
+	}
+	config, err := json.Marshal(configs)
+	if err != nil {
+		return nil, fmt.Errorf("cannot render copy targets config: %w", err)
+	}
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      GetBackupCopyTargetsSecretName(scope.Instance),
+			Namespace: scope.GetNamespace(),
+			Labels:    GetBackupDaemonLabels(scope.Instance),
+		},
+		Type: corev1.SecretTypeOpaque,
+		Data: map[string][]byte{
+			copyTargetsConfigKey: config,
+		},
+	}, nil
+}
+
+// NewBackupCopyStatus reports how far the copy target is behind the last completed backup at now.
+func NewBackupCopyStatus(now time.Time, name string, lastBackup string, lastBackupTime time.Time, lastCopiedBackup string, lastCopyTime time.Time) types.BackupCopyStatus {
+	status := types.BackupCopyStatus{
+		Name:             name,
+		LastBackup:       lastBackup,
+		LastCopiedBackup: lastCopiedBackup,
+	}
+	if !lastCopyTime.IsZero() {
+		status.LastCopyTime = &metav1.Time{Time: lastCopyTime}
+	}
+	if lastCopiedBackup != lastBackup && !lastBackupTime.IsZero() {
+		status.LagSeconds = int64(now.Sub(lastBackupTime).Seconds())
+	}
+	return status
+}
+
+// SetBackupCopyStatuses replaces copy statuses in status, statuses of removed targets are dropped.
+func SetBackupCopyStatuses(status *types.BackupDaemonStatus, copyTargets []types.BackupCopyTarget, statuses []types.BackupCopyStatus) {
+	byName := map[string]types.BackupCopyStatus{}
+	for _, copyStatus := range statuses {
+		byName[copyStatus.Name] = copyStatus
+	}
+	status.CopyTargets = nil
+	for _, target := range copyTargets {
+		if copyStatus, ok := byName[target.Name]; ok {
+			status.CopyTargets = append(status.CopyTargets, copyStatus)
+		}
+	}
+}
+
+func getCopyTargetConfig(target types.BackupCopyTarget) copyTargetConfig {
+	config := copyTargetConfig{
+		Name:           target.Name,
+		Type:           target.Type,
+		EvictionPolicy: target.EvictionPolicy,
+	}
+	switch target.Type {
+	case storage.TypeS3:
+		if target.S3Storage != nil {
+			config.S3 = getS3TargetConfig(target.Name, target.S3Storage)
+		}
+	case storage.TypeGcs:
+		if gcs := target.GcsStorage; gcs != nil {
+			config.Gcs = &gcsTargetConfig{Bucket: gcs.Bucket, Prefix: gcs.Prefix}
+			if gcs.Credentials != nil {
+				config.Gcs.CredentialsFile = copyCredentialsDir + getCopyCredentialsFile(target.Name, "gcs-credentials")
+			}
+		}
+	case storage.TypeAzure:
+		if azure := target.AzureStorage; azure != nil {
+			config.Azure = &azureTargetConfig{
+				AccountName: azure.AccountName,
+				Container:   azure.Container,
+				Prefix:      azure.Prefix,
+				Endpoint:    azure.Endpoint,
+			}
+			if azure.AccountKey != nil {
+				config.Azure.AccountKeyFile = copyCredentialsDir + getCopyCredentialsFile(target.Name, "azure-account-key")
+			}
+			if azure.SasToken != nil {
+				config.Azure.SasTokenFile = copyCredentialsDir + getCopyCredentialsFile(target.Name, "azure-sas-token")
+			}
+		}
+	case storage.TypePv:
+		config.Path = copyTargetDataDir + target.Name
+	}
+	return config
+}
+
+func getS3TargetConfig(target string, s3Storage *types.S3Storage) *s3TargetConfig {
+	config := &s3TargetConfig{
+		Url:             s3Storage.Url,
+		AccessKeyId:     s3Storage.AccessKeyId,
+		SecretAccessKey: s3Storage.SecretAccessKey,
+		Bucket:          s3Storage.Bucket,
+		Prefix:          s3Storage.Prefix,
+		Region:          s3Storage.Region,
+		UntrustedCert:   s3Storage.UntrustedCert,
+		AddressingStyle: s3Storage.AddressingStyle,
+		StorageClass:    s3Storage.StorageClass,
+	}
+	if _, ok := getS3CaBundleVolume(s3Storage.CaBundle); ok {
+		config.CaBundleFile = copyCredentialsDir + getCopyCredentialsFile(target, "s3-ca-bundle")
+	}
+	if sse := s3Storage.ServerSideEncryption; sse != nil {
+		config.Sse = sse.Type
+		if sse.Type == S3SseKms {
+			config.SseKmsKeyId = sse.KmsKeyId
+		}
+	}
+	if lock := s3Storage.ObjectLock; lock != nil {
+		if retention, err := util.ParseAge(lock.Retention); err == nil {
+			config.ObjectLockMode = strings.ToUpper(lock.Mode)
+			config.ObjectLockRetentionSeconds = int64(retention.Seconds())
+		} else {
+			logger.Error("Skipping S3 object lock settings of copy target", zap.String("target", target), zap.Error(err))
+		}
+	}
+	return config
+}
+
+func getCopyCredentialsFile(target string, kind string) string {
+	return fmt.Sprintf("%s-%s", target, kind)
+}
+
+// setCopyTargets mounts copy targets config, their credentials and claims into the daemon.
+func setCopyTargets(deployment *appsv1.Deployment, instance string, copyTargets []types.BackupCopyTarget) {
+	if len(copyTargets) == 0 {
+		return
+	}
+	podSpec := &deployment.Spec.Template.Spec
+	container := mainContainer(deployment)
+	podSpec.Volumes = append(podSpec.Volumes, corev1.Volume{
+		Name: copyTargetsVolumeName,
+		VolumeSource: corev1.VolumeSource{
+			Secret: &corev1.SecretVolumeSource{SecretName: GetBackupCopyTargetsSecretName(instance)},
+		},
+	})
+	container.VolumeMounts = append(container.VolumeMounts, corev1.VolumeMount{
+		MountPath: copyTargetsDir,
+		Name:      copyTargetsVolumeName,
+		ReadOnly:  true,
+	})
+	container.Env = append(container.Env, corev1.EnvVar{
+		Name:  "BACKUP_COPY_TARGETS_CONFIG",
+		Value: copyTargetsDir + copyTargetsConfigKey,
+	})
+	template := &deployment.Spec.Template
+	template.Annotations = util.Merge(template.Annotations, map[string]string{
+		CopyTargetsHashAnnotation: util.HashJson(copyTargets),
+	})
+
+	var credentials []corev1.VolumeProjection
+	addCredentials := func(selector *corev1.SecretKeySelector, file string) {
+		if selector != nil {
+			credentials = append(credentials, corev1.VolumeProjection{
+				Secret: &corev1.SecretProjection{
+					LocalObjectReference: selector.LocalObjectReference,
+					Items:                []corev1.KeyToPath{{Key: selector.Key, Path: file}},
+				},
+			})
+		}
+	}
+	for _, target := range copyTargets {
+		switch target.Type {
+		case storage.TypeS3:
+			if target.S3Storage == nil || target.S3Storage.CaBundle == nil {
+				continue
+			}
+			file := getCopyCredentialsFile(target.Name, "s3-ca-bundle")
+			if caBundle := target.S3Storage.CaBundle; caBundle.ConfigMap != nil {
+				credentials = append(credentials, corev1.VolumeProjection{
+					ConfigMap: &corev1.ConfigMapProjection{
+						LocalObjectReference: caBundle.ConfigMap.LocalObjectReference,
+						Items:                []corev1.KeyToPath{{Key: caBundle.ConfigMap.Key, Path: file}},
+					},
+				})
+			} else {
+				addCredentials(caBundle.Secret, file)
+			}
+		case storage.TypeGcs:
+			if target.GcsStorage != nil {
+				addCredentials(target.GcsStorage.Credentials, getCopyCredentialsFile(target.Name, "gcs-credentials"))
+			}
+		case storage.TypeAzure:
+			if target.AzureStorage != nil {
+				addCredentials(target.AzureStorage.AccountKey, getCopyCredentialsFile(target.Name, "azure-account-key"))
+				addCredentials(target.AzureStorage.SasToken, getCopyCredentialsFile(target.Name, "azure-sas-token"))
+			}
+		case storage.TypePv:
+			volumeName := util.DNSName(copyTargetDataVolumePrefix + target.Name)
+			podSpec.Volumes = append(podSpec.Volumes, corev1.Volume{
+				Name: volumeName,
+				VolumeSource: corev1.VolumeSource{
+					PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: target.ClaimName},
+				},
+			})
+			container.VolumeMounts = append(container.VolumeMounts, corev1.VolumeMount{
+				MountPath: copyTargetDataDir + target.Name,
+				Name:      volumeName,
+			})
+		}
+	}
+	if len(credentials) > 0 {
+		podSpec.Volumes = append(podSpec.Volumes, corev1.Volume{
+			Name: copyCredentialsVolumeName,
+			VolumeSource: corev1.VolumeSource{
+				Projected: &corev1.ProjectedVolumeSource{Sources: credentials},
+			},
+		})
+		container.VolumeMounts = append(container.VolumeMounts, corev1.VolumeMount{
+			MountPath: copyCredentialsDir,
+			Name:      copyCredentialsVolumeName,
+			ReadOnly:  true,
+		})
+	}
+}
@@ -0,0 +1,122 @@
+// Copyright 2024-2025 NetCracker Technology Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reconciler
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	types "github.com/Netcracker/pgskipper-operator-core/api/v1"
+	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// granularScheduleConfig is the schedule as the daemon reads it, dump options are rendered
+// as pg_dump arguments the same way as GRANULAR_DUMP_ARGS.
+type granularScheduleConfig struct {
+	Database        string   `json:"database,omitempty"`
+	DatabasePattern string   `json:"databasePattern,omitempty"`
+	Exclude         []string `json:"exclude,omitempty"`
+	Schedule        string   `json:"schedule"`
+	Retention       string   `json:"retention,omitempty"`
+	DumpArgs        []string `json:"dumpArgs,omitempty"`
+}
+
+// getGranularSchedulesEnvs renders structured schedules for the daemon. Validation forbids them
+// together with legacy DatabasesToSchedule and GranularBackupSchedule, so only one is in effect.
+func getGranularSchedulesEnvs(backupDaemon *types.BackupDaemon) []corev1.EnvVar {
+	if len(backupDaemon.GranularSchedules) == 0 {
+		return []corev1.EnvVar{}
+	}
+	configs := make([]granularScheduleConfig, 0, len(backupDaemon.GranularSchedules))
+	for _, granular := range backupDaemon.GranularSchedules {
+		configs = append(configs, granularScheduleConfig{
+			Database:        granular.Database,
+			DatabasePattern: granular.DatabasePattern,
+			Exclude:         granular.Exclude,
+			Schedule:        granular.Schedule,
+			Retention:       granular.Retention,
+			DumpArgs:        GetGranularDumpArgs(granular.DumpOptions),
+		})
+	}
+	schedules, err := json.Marshal(configs)
+	if err != nil {
+		logger.Error("Failed to render granular schedules", zap.Error(err))
+		return []corev1.EnvVar{}
+	}
+	return []corev1.EnvVar{
+		{
+			Name:  "GRANULAR_SCHEDULES",
+			Value: string(schedules),
+		},
+	}
+}
+
+func getExcludedExtensions(backupDaemon *types.BackupDaemon) string {
+	if options := backupDaemon.GranularDumpOptions; options != nil && len(options.ExcludedExtensions) > 0 {
+		return strings.Join(options.ExcludedExtensions, ",")
+	}
+	return backupDaemon.ExcludedExtensions
+}
+
+// GetGranularDumpArgs renders dump options as pg_dump arguments.
+func GetGranularDumpArgs(options *types.GranularDumpOptions) []string {
+	var args []string
+	if options == nil {
+		return args
+	}
+	if options.Format != "" {
+		args = append(args, fmt.Sprintf("--format=%s", options.Format))
+	}
+	if options.Jobs > 0 {
+		args = append(args, fmt.Sprintf("--jobs=%d", options.Jobs))
+	}
+	for _, schema := range options.Schemas {
+		args = append(args, fmt.Sprintf("--schema=%s", schema))
+	}
+	for _, schema := range options.ExcludedSchemas {
+		args = append(args, fmt.Sprintf("--exclude-schema=%s", schema))
+	}
+	for _, table := range options.ExcludedTables {
+		args = append(args, fmt.Sprintf("--exclude-table=%s", table))
+	}
+	if options.NoOwner {
+		args = append(args, "--no-owner")
+	}
+	if options.NoPrivileges {
+		args = append(args, "--no-privileges")
+	}
+	return args
+}
+
+// getGranularDumpEnvs passes pg_dump arguments as JSON array, so names with spaces survive.
+func getGranularDumpEnvs(options *types.GranularDumpOptions) []corev1.EnvVar {
+	args := GetGranularDumpArgs(options)
+	if len(args) == 0 {
+		return []corev1.EnvVar{}
+	}
+	rendered, err := json.Marshal(args)
+	if err != nil {
+		logger.Error("Failed to render granular dump options", zap.Error(err))
+		return []corev1.EnvVar{}
+	}
+	return []corev1.EnvVar{
+		{
+			Name:  "GRANULAR_DUMP_ARGS",
+			Value: string(rendered),
+		},
+	}
+}
@@ -0,0 +1,198 @@
+// Copyright 2024-2025 NetCracker Technology Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reconciler
+
+import (
+	"encoding/json"
+	"fmt"
+
+	types "github.com/Netcracker/pgskipper-operator-core/api/v1"
+	"github.com/Netcracker/pgskipper-operator-core/pkg/util"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	BackupHooksConfigMap = "postgres-backup-daemon-hooks"
+	HooksHashAnnotation  = "qubership.org/hooks-hash"
+
+	hooksConfigKey         = "hooks.json"
+	hooksDir               = "/hooks/"
+	hooksVolumeName        = "backup-hooks"
+	hooksSecretsDir        = "/hooks-secrets/"
+	hooksSecretsVolumeName = "backup-hooks-secrets"
+)
+
+// hooksConfig is hooks as the daemon reads them, header values from Secrets are replaced
+// with paths of the mounted files.
+type hooksConfig struct {
+	PreBackup  []hookConfig `json:"preBackup,omitempty"`
+	PostBackup []hookConfig `json:"postBackup,omitempty"`
+}
+
+type hookConfig struct {
+	Name          string          `json:"name"`
+	Sql           []string        `json:"sql,omitempty"`
+	Exec          []string        `json:"exec,omitempty"`
+	Http          *httpHookConfig `json:"http,omitempty"`
+	Timeout       string          `json:"timeout,omitempty"`
+	FailurePolicy string          `json:"failurePolicy,omitempty"`
+}
+
+type httpHookConfig struct {
+	Url     string             `json:"url"`
+	Method  string             `json:"method,omitempty"`
+	Headers []httpHeaderConfig `json:"headers,omitempty"`
+	Body    string             `json:"body,omitempty"`
+}
+
+type httpHeaderConfig struct {
+	Name      string `json:"name"`
+	Value     string `json:"value,omitempty"`
+	ValueFile string `json:"valueFile,omitempty"`
+}
+
+func GetBackupHooksConfigMapName(instance string) string {
+	return util.InstanceName(BackupHooksConfigMap, instance)
+}
+
+// NewBackupHooksConfigMap renders backup hooks for the daemon. Nil is returned when there are no hooks.
+func NewBackupHooksConfigMap(scope Scope, hooks *types.BackupHooks) (*corev1.ConfigMap, error) {
+	if !hasHooks(hooks) {
+		return nil, nil
+	}
+	config, err := json.Marshal(hooksConfig{
+		PreBackup:  getHookConfigs("pre", hooks.PreBackup),
+		PostBackup: getHookConfigs("post", hooks.PostBackup),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("cannot render backup hooks: %w", err)
+	}
+	return &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      GetBackupHooksConfigMapName(scope.Instance),
+			Namespace: scope.GetNamespace(),
+			Labels:    GetBackupDaemonLabels(scope.Instance),
+		},
+		Data: map[string]string{
+			hooksConfigKey: string(config),
+		},
+	}, nil
+}
+
+func getHookConfigs(phase string, hooks []types.BackupHook) []hookConfig {
+	configs := make([]hookConfig, 0, len(hooks))
+	for _, hook := range hooks {
+		config := hookConfig{
+			Name:          hook.Name,
+			Sql:           hook.Sql,
+			Exec:          hook.Exec,
+			Timeout:       hook.Timeout,
+			FailurePolicy: hook.FailurePolicy,
+		}
+		if hook.Http != nil {
+			config.Http = &httpHookConfig{
+				Url:    hook.Http.Url,
+				Method: hook.Http.Method,
+				Body:   hook.Http.Body,
+			}
+			for i, header := range hook.Http.Headers {
+				headerConfig := httpHeaderConfig{Name: header.Name, Value: header.Value}
+				if header.ValueFrom != nil {
+					headerConfig.ValueFile = hooksSecretsDir + getHookHeaderFile(phase, hook.Name, i)
+				}
+				config.Http.Headers = append(config.Http.Headers, headerConfig)
+			}
+		}
+		configs = append(configs, config)
+	}
+	return configs
+}
+
+func getHookHeaderFile(phase string, hook string, index int) string {
+	return fmt.Sprintf("%s-%s-header-%d", phase, hook, index)
+}
+
+// getHookSecretProjections projects Secrets of header values of hooks into files.
+func getHookSecretProjections(phase string, hooks []types.BackupHook) []corev1.VolumeProjection {
+	var projections []corev1.VolumeProjection
+	for _, hook := range hooks {
+		if hook.Http == nil {
+			continue
+		}
+		for i, header := range hook.Http.Headers {
+			if header.ValueFrom == nil {
+				continue
+			}
+			projections = append(projections, corev1.VolumeProjection{
+				Secret: &corev1.SecretProjection{
+					LocalObjectReference: header.ValueFrom.LocalObjectReference,
+					Items:                []corev1.KeyToPath{{Key: header.ValueFrom.Key, Path: getHookHeaderFile(phase, hook.Name, i)}},
+				},
+			})
+		}
+	}
+	return projections
+}
+
+func hasHooks(hooks *types.BackupHooks) bool {
+	return hooks != nil && (len(hooks.PreBackup) > 0 || len(hooks.PostBackup) > 0)
+}
+
+// setBackupHooks mounts the hooks config map into the daemon. The config map hash is added
+// to the pod template, so the daemon restarts and rereads hooks when they change.
+func setBackupHooks(deployment *appsv1.Deployment, instance string, hooks *types.BackupHooks) {
+	if !hasHooks(hooks) {
+		return
+	}
+	podSpec := &deployment.Spec.Template.Spec
+	podSpec.Volumes = append(podSpec.Volumes, corev1.Volume{
+		Name: hooksVolumeName,
+		VolumeSource: corev1.VolumeSource{
+			ConfigMap: &corev1.ConfigMapVolumeSource{
+				LocalObjectReference: corev1.LocalObjectReference{Name: GetBackupHooksConfigMapName(instance)},
+			},
+		},
+	})
+	container := mainContainer(deployment)
+	container.VolumeMounts = append(container.VolumeMounts, corev1.VolumeMount{
+		MountPath: hooksDir,
+		Name:      hooksVolumeName,
+		ReadOnly:  true,
+	})
+	container.Env = append(container.Env, corev1.EnvVar{
+		Name:  "BACKUP_HOOKS_CONFIG",
+		Value: hooksDir + hooksConfigKey,
+	})
+	secrets := append(getHookSecretProjections("pre", hooks.PreBackup), getHookSecretProjections("post", hooks.PostBackup)...)
+	if len(secrets) > 0 {
+		podSpec.Volumes = append(podSpec.Volumes, corev1.Volume{
+			Name: hooksSecretsVolumeName,
+			VolumeSource: corev1.VolumeSource{
+				Projected: &corev1.ProjectedVolumeSource{Sources: secrets},
+			},
+		})
+		container.VolumeMounts = append(container.VolumeMounts, corev1.VolumeMount{
+			MountPath: hooksSecretsDir,
+			Name:      hooksSecretsVolumeName,
+			ReadOnly:  true,
+		})
+	}
+	template := &deployment.Spec.Template
+	template.Annotations = util.Merge(template.Annotations, map[string]string{
+		HooksHashAnnotation: util.HashJson(hooks),
+	})
+}
@@ -36,30 +36,75 @@ const (
 	MetricCollectorUserCredentials = "monitoring-credentials"
 	influxDbAdminCredentials       = "influx-db-admin-credentials"
 	telegrafConfig                 = "telegraf-configmap"
+	influxDbTelegrafConfig         = "influxdb-telegraf-configmap"
 )
 
-func NewMonitoringDeployment(metricCollector *types.MetricCollector, pgcluster string, serviceAccountName string) *appsv1.Deployment {
-	// metricCollector := cr.Spec.MetricCollector
+func GetMetricCollectorName(instance string) string {
+	return util.InstanceName(MetricCollectorDeploymentName, instance)
+}
+
+// GetMetricCollectorLabels scopes app label of an instance as well, as the legacy selector
+// only has app label and would otherwise match collector pods of all instances.
+func GetMetricCollectorLabels(instance string) map[string]string {
+	if instance == "" {
+		// a copy, as callers put labels on objects and may change them
+		return util.Merge(MetricCollectorLabels)
+	}
+	name := GetMetricCollectorName(instance)
+	return instanceLabels(map[string]string{"app": name, "name": name}, instance)
+}
+
+func GetMetricCollectorCredentialsName(instance string) string {
+	return util.InstanceName(MetricCollectorUserCredentials, instance)
+}
+
+func GetInfluxDbAdminCredentialsName(instance string) string {
+	return util.InstanceName(influxDbAdminCredentials, instance)
+}
+
+func GetTelegrafConfigMapName(instance string) string {
+	return util.InstanceName(telegrafConfig, instance)
+}
+
+func GetInfluxDbTelegrafConfigMapName(instance string) string {
+	return util.InstanceName(influxDbTelegrafConfig, instance)
+}
+
+func NewMonitoringDeployment(metricCollector *types.MetricCollector, pgcluster string, serviceAccountName string, opts ...DeploymentOption) *appsv1.Deployment {
+	return NewMonitoringDeploymentForInstance("", metricCollector, pgcluster, serviceAccountName, opts...)
+}
+
+// NewMonitoringDeploymentForInstance builds metric collector deployment with object names,
+// labels and config map references scoped to instance.
+func NewMonitoringDeploymentForInstance(instance string, metricCollector *types.MetricCollector, pgcluster string, serviceAccountName string, opts ...DeploymentOption) *appsv1.Deployment {
+	return NewMonitoringDeploymentInScope(Scope{Instance: instance}, metricCollector, pgcluster, serviceAccountName, opts...)
+}
+
+// NewMonitoringDeploymentInScope builds metric collector deployment in the namespace and for the instance of scope.
+// Options are applied to the deployment after it is built.
+func NewMonitoringDeploymentInScope(scope Scope, metricCollector *types.MetricCollector, pgcluster string, serviceAccountName string, opts ...DeploymentOption) *appsv1.Deployment {
+	instance := scope.Instance
+	labels := GetMetricCollectorLabels(instance)
 	sslMode := "prefer"
 	if metricCollector.SslMode != "" {
 		sslMode = metricCollector.SslMode
 	}
 	deployment := &appsv1.Deployment{
 		ObjectMeta: metav1.ObjectMeta{
-			Name:      MetricCollectorDeploymentName,
-			Namespace: util.GetNameSpace(),
-			Labels:    util.Merge(MetricCollectorLabels, metricCollector.PodLabels),
+			Name:      GetMetricCollectorName(instance),
+			Namespace: scope.GetNamespace(),
+			Labels:    util.Merge(labels, metricCollector.PodLabels),
 		},
 		Spec: appsv1.DeploymentSpec{
 			Strategy: appsv1.DeploymentStrategy{
 				Type: appsv1.RollingUpdateDeploymentStrategyType,
 			},
 			Selector: &metav1.LabelSelector{
-				MatchLabels: util.Merge(MetricCollectorLabels, metricCollector.PodLabels),
+				MatchLabels: util.Merge(labels, metricCollector.PodLabels),
 			},
 			Template: corev1.PodTemplateSpec{
 				ObjectMeta: metav1.ObjectMeta{
-					Labels: util.Merge(MetricCollectorLabels, metricCollector.PodLabels),
+					Labels: util.Merge(labels, metricCollector.PodLabels),
 				},
 				Spec: corev1.PodSpec{
 					ServiceAccountName: serviceAccountName,
@@ -69,7 +114,7 @@ func NewMonitoringDeployment(metricCollector *types.MetricCollector, pgcluster s
 							Name: "telegraf-config-volume",
 							VolumeSource: corev1.VolumeSource{
 								ConfigMap: &corev1.ConfigMapVolumeSource{
-									LocalObjectReference: corev1.LocalObjectReference{Name: telegrafConfig},
+									LocalObjectReference: corev1.LocalObjectReference{Name: GetTelegrafConfigMapName(instance)},
 								},
 							},
 						},
@@ -86,7 +131,7 @@ func NewMonitoringDeployment(metricCollector *types.MetricCollector, pgcluster s
 									Name: "MONITORING_USER",
 									ValueFrom: &corev1.EnvVarSource{
 										SecretKeyRef: &corev1.SecretKeySelector{
-											LocalObjectReference: corev1.LocalObjectReference{Name: MetricCollectorUserCredentials},
+											LocalObjectReference: corev1.LocalObjectReference{Name: GetMetricCollectorCredentialsName(instance)},
 											Key:                  "username",
 										},
 									},
@@ -95,7 +140,7 @@ func NewMonitoringDeployment(metricCollector *types.MetricCollector, pgcluster s
 									Name: "MONITORING_PASSWORD",
 									ValueFrom: &corev1.EnvVarSource{
 										SecretKeyRef: &corev1.SecretKeySelector{
-											LocalObjectReference: corev1.LocalObjectReference{Name: MetricCollectorUserCredentials},
+											LocalObjectReference: corev1.LocalObjectReference{Name: GetMetricCollectorCredentialsName(instance)},
 											Key:                  "password",
 										},
 									},
@@ -104,7 +149,7 @@ func NewMonitoringDeployment(metricCollector *types.MetricCollector, pgcluster s
 									Name: "PG_ROOT_USER",
 									ValueFrom: &corev1.EnvVarSource{
 										SecretKeyRef: &corev1.SecretKeySelector{
-											LocalObjectReference: corev1.LocalObjectReference{Name: GetRootSecretName(pgcluster)},
+											LocalObjectReference: corev1.LocalObjectReference{Name: GetRootSecretNameForInstance(pgcluster, instance)},
 											Key:                  "username",
 										},
 									},
@@ -113,7 +158,7 @@ func NewMonitoringDeployment(metricCollector *types.MetricCollector, pgcluster s
 									Name: "PG_ROOT_PASSWORD",
 									ValueFrom: &corev1.EnvVarSource{
 										SecretKeyRef: &corev1.SecretKeySelector{
-											LocalObjectReference: corev1.LocalObjectReference{Name: GetRootSecretName(pgcluster)},
+											LocalObjectReference: corev1.LocalObjectReference{Name: GetRootSecretNameForInstance(pgcluster, instance)},
 											Key:                  "password",
 										},
 									},
@@ -122,7 +167,7 @@ func NewMonitoringDeployment(metricCollector *types.MetricCollector, pgcluster s
 									Name: "INFLUXDB_USER",
 									ValueFrom: &corev1.EnvVarSource{
 										SecretKeyRef: &corev1.SecretKeySelector{
-											LocalObjectReference: corev1.LocalObjectReference{Name: influxDbAdminCredentials},
+											LocalObjectReference: corev1.LocalObjectReference{Name: GetInfluxDbAdminCredentialsName(instance)},
 											Key:                  "username",
 										},
 									},
@@ -131,7 +176,7 @@ func NewMonitoringDeployment(metricCollector *types.MetricCollector, pgcluster s
 									Name: "INFLUXDB_PASSWORD",
 									ValueFrom: &corev1.EnvVarSource{
 										SecretKeyRef: &corev1.SecretKeySelector{
-											LocalObjectReference: corev1.LocalObjectReference{Name: influxDbAdminCredentials},
+											LocalObjectReference: corev1.LocalObjectReference{Name: GetInfluxDbAdminCredentialsName(instance)},
 											Key:                  "password",
 										},
 									},
@@ -174,7 +219,7 @@ func NewMonitoringDeployment(metricCollector *types.MetricCollector, pgcluster s
 								},
 								{
 									Name:  "POSTGRESQL_CREDENTIALS",
-									Value: GetRootSecretName(pgcluster),
+									Value: GetRootSecretNameForInstance(pgcluster, instance),
 								},
 								{
 									Name:  "PATRONI_ENTITY_TYPE",
@@ -184,6 +229,10 @@ func NewMonitoringDeployment(metricCollector *types.MetricCollector, pgcluster s
 									Name:  "PGSSLMODE",
 									Value: sslMode,
 								},
+								{
+									Name:  "PGPORT",
+									Value: strconv.Itoa(withDefault(metricCollector.PgPort, DefaultPgPort)),
+								},
 							}, getDevEnvs(metricCollector)...),
 							VolumeMounts: []corev1.VolumeMount{
 								{
@@ -203,15 +252,27 @@ func NewMonitoringDeployment(metricCollector *types.MetricCollector, pgcluster s
 	if metricCollector.PriorityClassName != "" {
 		deployment.Spec.Template.Spec.PriorityClassName = metricCollector.PriorityClassName
 	}
+	setScheduling(&deployment.Spec.Template.Spec, metricCollector.NodeSelector, metricCollector.Tolerations,
+		metricCollector.TopologySpreadConstraints, metricCollector.RuntimeClassName)
 
 	if metricCollector.InfluxDbHost != "" {
-		deployment.Spec.Template.Spec.Volumes = append(deployment.Spec.Template.Spec.Volumes, getInfluxConfigMapVolume())
+		deployment.Spec.Template.Spec.Volumes = append(deployment.Spec.Template.Spec.Volumes, getInfluxConfigMapVolume(instance))
 		deployment.Spec.Template.Spec.Containers[0].VolumeMounts = append(deployment.Spec.Template.Spec.Containers[0].VolumeMounts, getInfluxConfigMapVolumeMount())
 	}
-	return deployment
+	setPgSsl(deployment, metricCollector.Ssl)
+	return applyDeploymentOptions(deployment, opts)
 }
 
 func ConfigMapForTelegraf() *corev1.ConfigMap {
+	return ConfigMapForTelegrafForInstance("")
+}
+
+func ConfigMapForTelegrafForInstance(instance string) *corev1.ConfigMap {
+	return ConfigMapForTelegrafInScope(Scope{Instance: instance})
+}
+
+func ConfigMapForTelegrafInScope(scope Scope) *corev1.ConfigMap {
+	instance := scope.Instance
 	filePath := "/opt/operator/telegraf-configmap"
 	bytes, e := ioutil.ReadFile(filePath)
 	if e != nil {
@@ -219,9 +280,9 @@ func ConfigMapForTelegraf() *corev1.ConfigMap {
 	}
 	configMap := &corev1.ConfigMap{
 		ObjectMeta: metav1.ObjectMeta{
-			Name:      telegrafConfig,
-			Namespace: util.GetNameSpace(),
-			Labels:    MetricCollectorLabels,
+			Name:      GetTelegrafConfigMapName(instance),
+			Namespace: scope.GetNamespace(),
+			Labels:    GetMetricCollectorLabels(instance),
 		},
 		Data: map[string]string{
 			"telegraf_temp.conf": string(bytes),
@@ -231,6 +292,15 @@ func ConfigMapForTelegraf() *corev1.ConfigMap {
 }
 
 func ConfigMapForInfluxdbTelegraf() *corev1.ConfigMap {
+	return ConfigMapForInfluxdbTelegrafForInstance("")
+}
+
+func ConfigMapForInfluxdbTelegrafForInstance(instance string) *corev1.ConfigMap {
+	return ConfigMapForInfluxdbTelegrafInScope(Scope{Instance: instance})
+}
+
+func ConfigMapForInfluxdbTelegrafInScope(scope Scope) *corev1.ConfigMap {
+	instance := scope.Instance
 	filePath := "/opt/operator/influxdb-telegraf-configmap"
 	bytes, e := ioutil.ReadFile(filePath)
 	if e != nil {
@@ -238,9 +308,9 @@ func ConfigMapForInfluxdbTelegraf() *corev1.ConfigMap {
 	}
 	configMap := &corev1.ConfigMap{
 		ObjectMeta: metav1.ObjectMeta{
-			Name:      "influxdb-telegraf-configmap",
-			Namespace: util.GetNameSpace(),
-			Labels:    map[string]string{"app": "monitoring-collector"},
+			Name:      GetInfluxDbTelegrafConfigMapName(instance),
+			Namespace: scope.GetNamespace(),
+			Labels:    GetMetricCollectorLabels(instance),
 		},
 		Data: map[string]string{
 			"influxdb-telegraf_temp.conf": string(bytes),
@@ -256,12 +326,12 @@ func GetPortsForMonitoringService() []corev1.ServicePort {
 	}
 }
 
-func getInfluxConfigMapVolume() corev1.Volume {
+func getInfluxConfigMapVolume(instance string) corev1.Volume {
 	return corev1.Volume{
 		Name: "influxdb-telegraf-config-volume",
 		VolumeSource: corev1.VolumeSource{
 			ConfigMap: &corev1.ConfigMapVolumeSource{
-				LocalObjectReference: corev1.LocalObjectReference{Name: "influxdb-telegraf-configmap"},
+				LocalObjectReference: corev1.LocalObjectReference{Name: GetInfluxDbTelegrafConfigMapName(instance)},
 			},
 		},
 	}
@@ -0,0 +1,208 @@
+// Copyright 2024-2025 NetCracker Technology Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reconciler
+
+import (
+	"encoding/json"
+	"fmt"
+
+	types "github.com/Netcracker/pgskipper-operator-core/api/v1"
+	"github.com/Netcracker/pgskipper-operator-core/pkg/util"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	BackupNotificationsConfigMap = "postgres-backup-daemon-notifications"
+	NotificationsHashAnnotation  = "qubership.org/notifications-hash"
+	NotifierContainer            = "notifier"
+	NotifierPort                 = 8090
+
+	NotificationTypeHttp  = "http"
+	NotificationTypeSlack = "slack"
+	NotificationTypeTeams = "teams"
+
+	EventSuccess  = "success"
+	EventFailure  = "failure"
+	EventEviction = "eviction"
+
+	notificationsConfigKey     = "notifications.json"
+	notificationsDir           = "/notifications/"
+	notificationsVolumeName    = "notifications"
+	notificationUrlsDir        = "/notifications-urls/"
+	notificationUrlsVolumeName = "notifications-urls"
+)
+
+var (
+	NotificationTypes  = []string{NotificationTypeHttp, NotificationTypeSlack, NotificationTypeTeams}
+	NotificationEvents = []string{EventSuccess, EventFailure, EventEviction}
+)
+
+// notificationTargetConfig is the target as the notifier reads it, the URL Secret
+// is replaced with the path of the mounted file.
+type notificationTargetConfig struct {
+	Name    string   `json:"name"`
+	Type    string   `json:"type"`
+	UrlFile string   `json:"urlFile"`
+	Events  []string `json:"events"`
+}
+
+func GetBackupNotificationsConfigMapName(instance string) string {
+	return util.InstanceName(BackupNotificationsConfigMap, instance)
+}
+
+// NewBackupNotificationsConfigMap renders notification targets. Nil is returned when there are no targets.
+func NewBackupNotificationsConfigMap(scope Scope, notifications *types.BackupNotifications) (*corev1.ConfigMap, error) {
+	if !hasNotifications(notifications) {
+		return nil, nil
+	}
+	config, err := json.Marshal(getNotificationTargetConfigs(notifications.Targets))
+	if err != nil {
+		return nil, fmt.Errorf("cannot render notifications config: %w", err)
+	}
+	return &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      GetBackupNotificationsConfigMapName(scope.Instance),
+			Namespace: scope.GetNamespace(),
+			Labels:    GetBackupDaemonLabels(scope.Instance),
+		},
+		Data: map[string]string{
+			notificationsConfigKey: string(config),
+		},
+	}, nil
+}
+
+func hasNotifications(notifications *types.BackupNotifications) bool {
+	return notifications != nil && len(notifications.Targets) > 0
+}
+
+func getNotificationTargetConfigs(targets []types.NotificationTarget) []notificationTargetConfig {
+	configs := make([]notificationTargetConfig, 0, len(targets))
+	for _, target := range targets {
+		events := target.Events
+		if len(events) == 0 {
+			events = NotificationEvents
+		}
+		configs = append(configs, notificationTargetConfig{
+			Name:    target.Name,
+			Type:    target.Type,
+			UrlFile: notificationUrlsDir + getNotificationUrlFile(target.Name),
+			Events:  events,
+		})
+	}
+	return configs
+}
+
+func getNotificationUrlFile(target string) string {
+	return target + "-url"
+}
+
+// setNotifications mounts notifications config and webhook URLs into the daemon. With the notifier
+// image set they are mounted into the sidecar instead, and the daemon posts its events to it.
+func setNotifications(deployment *appsv1.Deployment, instance string, notifications *types.BackupNotifications) {
+	if !hasNotifications(notifications) {
+		return
+	}
+	podSpec := &deployment.Spec.Template.Spec
+	var urls []corev1.VolumeProjection
+	for _, target := range notifications.Targets {
+		if target.Url == nil {
+			continue
+		}
+		urls = append(urls, corev1.VolumeProjection{
+			Secret: &corev1.SecretProjection{
+				LocalObjectReference: target.Url.LocalObjectReference,
+				Items:                []corev1.KeyToPath{{Key: target.Url.Key, Path: getNotificationUrlFile(target.Name)}},
+			},
+		})
+	}
+	podSpec.Volumes = append(podSpec.Volumes,
+		corev1.Volume{
+			Name: notificationsVolumeName,
+			VolumeSource: corev1.VolumeSource{
+				ConfigMap: &corev1.ConfigMapVolumeSource{
+					LocalObjectReference: corev1.LocalObjectReference{Name: GetBackupNotificationsConfigMapName(instance)},
+				},
+			},
+		},
+		corev1.Volume{
+			Name: notificationUrlsVolumeName,
+			VolumeSource: corev1.VolumeSource{
+				Projected: &corev1.ProjectedVolumeSource{Sources: urls},
+			},
+		})
+	mounts := []corev1.VolumeMount{
+		{
+			MountPath: notificationsDir,
+			Name:      notificationsVolumeName,
+			ReadOnly:  true,
+		},
+		{
+			MountPath: notificationUrlsDir,
+			Name:      notificationUrlsVolumeName,
+			ReadOnly:  true,
+		},
+	}
+	configEnv := corev1.EnvVar{
+		Name:  "NOTIFICATIONS_CONFIG",
+		Value: notificationsDir + notificationsConfigKey,
+	}
+	container := mainContainer(deployment)
+	if notifier := notifications.Notifier; notifier != nil && notifier.DockerImage != "" {
+		sidecar := corev1.Container{
+			Name:  NotifierContainer,
+			Image: notifier.DockerImage,
+			Env: []corev1.EnvVar{
+				configEnv,
+				{
+					Name:  "NOTIFIER_PORT",
+					Value: fmt.Sprint(NotifierPort),
+				},
+			},
+			Ports:           []corev1.ContainerPort{{ContainerPort: NotifierPort, Protocol: corev1.ProtocolTCP}},
+			VolumeMounts:    mounts,
+			SecurityContext: container.SecurityContext,
+		}
+		if notifier.Resources != nil {
+			sidecar.Resources = *notifier.Resources
+		}
+		podSpec.Containers = append(podSpec.Containers, sidecar)
+		// append may have moved the containers
+		container = mainContainer(deployment)
+		container.Env = append(container.Env, corev1.EnvVar{
+			Name:  "NOTIFICATIONS_ENDPOINT",
+			Value: fmt.Sprintf("http://127.0.0.1:%d/events", NotifierPort),
+		})
+	} else {
+		container.VolumeMounts = append(container.VolumeMounts, mounts...)
+		container.Env = append(container.Env, configEnv)
+	}
+	template := &deployment.Spec.Template
+	template.Annotations = util.Merge(template.Annotations, map[string]string{
+		NotificationsHashAnnotation: util.HashJson(notifications.Targets),
+	})
+}
+
+// setNotifierPullPolicy gives the notifier sidecar the pull policy of the main container.
+// It runs after the deployment options, which may change the policy.
+func setNotifierPullPolicy(deployment *appsv1.Deployment) {
+	containers := deployment.Spec.Template.Spec.Containers
+	for i := range containers {
+		if containers[i].Name == NotifierContainer {
+			containers[i].ImagePullPolicy = containers[0].ImagePullPolicy
+		}
+	}
+}
@@ -0,0 +1,70 @@
+// Copyright 2024-2025 NetCracker Technology Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reconciler
+
+import (
+	"testing"
+
+	types "github.com/Netcracker/pgskipper-operator-core/api/v1"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestNotifierPullPolicy(t *testing.T) {
+	backupDaemon := &types.BackupDaemon{
+		DockerImage: "backup-daemon:latest",
+		Resources:   &corev1.ResourceRequirements{},
+		Notifications: &types.BackupNotifications{
+			Notifier: &types.NotifierSidecar{DockerImage: "notifier:latest"},
+			Targets: []types.NotificationTarget{{
+				Name: "ops",
+				Type: "slack",
+				Url:  &corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "webhooks"}, Key: "ops"},
+			}},
+		},
+	}
+	tests := map[string]struct {
+		opts     []DeploymentOption
+		expected corev1.PullPolicy
+	}{
+		"default": {},
+		"option": {
+			opts:     []DeploymentOption{WithImagePullPolicy(corev1.PullAlways)},
+			expected: corev1.PullAlways,
+		},
+		"mutator": {
+			opts: []DeploymentOption{WithContainerMutator(func(container *corev1.Container) {
+				container.ImagePullPolicy = corev1.PullNever
+			})},
+			expected: corev1.PullNever,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			deployment := NewBackupDaemonDeploymentInScope(Scope{Namespace: "pg"}, backupDaemon, "patroni", "postgres-sa", tt.opts...)
+			var notifier *corev1.Container
+			for i, container := range deployment.Spec.Template.Spec.Containers {
+				if container.Name == NotifierContainer {
+					notifier = &deployment.Spec.Template.Spec.Containers[i]
+				}
+			}
+			if notifier == nil {
+				t.Fatal("notifier sidecar is not added")
+			}
+			if notifier.ImagePullPolicy != tt.expected {
+				t.Errorf("notifier pull policy = %q, want %q", notifier.ImagePullPolicy, tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,156 @@
+// Copyright 2024-2025 NetCracker Technology Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reconciler
+
+import (
+	"github.com/Netcracker/pgskipper-operator-core/pkg/util"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// DeploymentOption customizes a deployment after it is built.
+// Options are applied in the order they are passed.
+type DeploymentOption func(*appsv1.Deployment)
+
+func applyDeploymentOptions(deployment *appsv1.Deployment, opts []DeploymentOption) *appsv1.Deployment {
+	for _, opt := range opts {
+		if opt != nil {
+			opt(deployment)
+		}
+	}
+	return deployment
+}
+
+// mainContainer returns the component container, which is always the first one,
+// sidecars are appended after it.
+func mainContainer(deployment *appsv1.Deployment) *corev1.Container {
+	return &deployment.Spec.Template.Spec.Containers[0]
+}
+
+func WithReplicas(replicas int32) DeploymentOption {
+	return func(deployment *appsv1.Deployment) {
+		deployment.Spec.Replicas = &replicas
+	}
+}
+
+func WithAnnotations(annotations map[string]string) DeploymentOption {
+	return func(deployment *appsv1.Deployment) {
+		deployment.Annotations = util.Merge(deployment.Annotations, annotations)
+	}
+}
+
+func WithPodAnnotations(annotations map[string]string) DeploymentOption {
+	return func(deployment *appsv1.Deployment) {
+		template := &deployment.Spec.Template
+		template.Annotations = util.Merge(template.Annotations, annotations)
+	}
+}
+
+// WithPodLabels adds labels to the pod template only, the selector is left intact.
+func WithPodLabels(labels map[string]string) DeploymentOption {
+	return func(deployment *appsv1.Deployment) {
+		template := &deployment.Spec.Template
+		template.Labels = util.Merge(template.Labels, labels)
+	}
+}
+
+func WithTolerations(tolerations ...corev1.Toleration) DeploymentOption {
+	return func(deployment *appsv1.Deployment) {
+		podSpec := &deployment.Spec.Template.Spec
+		podSpec.Tolerations = append(podSpec.Tolerations, tolerations...)
+	}
+}
+
+func WithImagePullSecrets(names ...string) DeploymentOption {
+	return func(deployment *appsv1.Deployment) {
+		podSpec := &deployment.Spec.Template.Spec
+		for _, name := range names {
+			podSpec.ImagePullSecrets = append(podSpec.ImagePullSecrets, corev1.LocalObjectReference{Name: name})
+		}
+	}
+}
+
+func WithImagePullPolicy(policy corev1.PullPolicy) DeploymentOption {
+	return func(deployment *appsv1.Deployment) {
+		mainContainer(deployment).ImagePullPolicy = policy
+	}
+}
+
+// WithEnv sets env variables of the main container, variables with the same name are replaced.
+func WithEnv(env ...corev1.EnvVar) DeploymentOption {
+	return func(deployment *appsv1.Deployment) {
+		container := mainContainer(deployment)
+		container.Env = mergeEnv(container.Env, env)
+	}
+}
+
+func WithVolumes(volumes ...corev1.Volume) DeploymentOption {
+	return func(deployment *appsv1.Deployment) {
+		podSpec := &deployment.Spec.Template.Spec
+		podSpec.Volumes = append(podSpec.Volumes, volumes...)
+	}
+}
+
+func WithVolumeMounts(mounts ...corev1.VolumeMount) DeploymentOption {
+	return func(deployment *appsv1.Deployment) {
+		container := mainContainer(deployment)
+		container.VolumeMounts = append(container.VolumeMounts, mounts...)
+	}
+}
+
+func WithSidecars(containers ...corev1.Container) DeploymentOption {
+	return func(deployment *appsv1.Deployment) {
+		podSpec := &deployment.Spec.Template.Spec
+		podSpec.Containers = append(podSpec.Containers, containers...)
+	}
+}
+
+func WithInitContainers(containers ...corev1.Container) DeploymentOption {
+	return func(deployment *appsv1.Deployment) {
+		podSpec := &deployment.Spec.Template.Spec
+		podSpec.InitContainers = append(podSpec.InitContainers, containers...)
+	}
+}
+
+// WithContainerMutator gives access to the main container for changes not covered by other options.
+func WithContainerMutator(mutate func(container *corev1.Container)) DeploymentOption {
+	return func(deployment *appsv1.Deployment) {
+		mutate(mainContainer(deployment))
+	}
+}
+
+// WithPodSpecMutator gives access to the pod spec for changes not covered by other options.
+func WithPodSpecMutator(mutate func(podSpec *corev1.PodSpec)) DeploymentOption {
+	return func(deployment *appsv1.Deployment) {
+		mutate(&deployment.Spec.Template.Spec)
+	}
+}
+
+func mergeEnv(env []corev1.EnvVar, overrides []corev1.EnvVar) []corev1.EnvVar {
+	for _, override := range overrides {
+		replaced := false
+		for i := range env {
+			if env[i].Name == override.Name {
+				env[i] = override
+				replaced = true
+				break
+			}
+		}
+		if !replaced {
+			env = append(env, override)
+		}
+	}
+	return env
+}
@@ -13,7 +13,72 @@
 // limitations under the License.
 
 package reconciler
+
 import (
+	types "github.com/Netcracker/pgskipper-operator-core/api/v1"
+	"github.com/Netcracker/pgskipper-operator-core/pkg/util"
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	InstanceLabel = "app.kubernetes.io/instance"
 )
 
+// Scope selects the namespace and the instance built objects belong to.
+// Empty Namespace falls back to WATCH_NAMESPACE, empty Instance keeps legacy names.
+type Scope struct {
+	Namespace string
+	Instance  string
+}
+
+func (s Scope) GetNamespace() string {
+	return util.NamespaceOrDefault(s.Namespace)
+}
+
+func setScheduling(podSpec *corev1.PodSpec, nodeSelector map[string]string, tolerations []corev1.Toleration,
+	constraints []corev1.TopologySpreadConstraint, runtimeClassName string) {
+	if len(nodeSelector) > 0 {
+		podSpec.NodeSelector = util.Merge(podSpec.NodeSelector, nodeSelector)
+	}
+	podSpec.Tolerations = append(podSpec.Tolerations, tolerations...)
+	podSpec.TopologySpreadConstraints = append(podSpec.TopologySpreadConstraints, constraints...)
+	if runtimeClassName != "" {
+		podSpec.RuntimeClassName = &runtimeClassName
+	}
+}
+
+func withDefault(value int, def int) int {
+	if value == 0 {
+		return def
+	}
+	return value
+}
+
+// applyProbeSettings overrides probe timings with non zero settings.
+func applyProbeSettings(probe *corev1.Probe, settings *types.ProbeSettings) *corev1.Probe {
+	if settings == nil {
+		return probe
+	}
+	if settings.InitialDelaySeconds > 0 {
+		probe.InitialDelaySeconds = settings.InitialDelaySeconds
+	}
+	if settings.PeriodSeconds > 0 {
+		probe.PeriodSeconds = settings.PeriodSeconds
+	}
+	if settings.TimeoutSeconds > 0 {
+		probe.TimeoutSeconds = settings.TimeoutSeconds
+	}
+	if settings.FailureThreshold > 0 {
+		probe.FailureThreshold = settings.FailureThreshold
+	}
+	return probe
+}
 
+// instanceLabels adds the instance label to labels, so selectors of
+// different instances in the same namespace do not overlap.
+func instanceLabels(labels map[string]string, instance string) map[string]string {
+	if instance == "" {
+		return labels
+	}
+	return util.Merge(labels, map[string]string{InstanceLabel: util.DNSName(instance)})
+}
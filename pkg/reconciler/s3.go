@@ -0,0 +1,163 @@
+// Copyright 2024-2025 NetCracker Technology Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reconciler
+
+import (
+	"strconv"
+	"strings"
+
+	types "github.com/Netcracker/pgskipper-operator-core/api/v1"
+	"github.com/Netcracker/pgskipper-operator-core/pkg/util"
+	"go.uber.org/zap"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	S3RoleArnAnnotation = "eks.amazonaws.com/role-arn"
+	S3SseS3             = "SSE-S3"
+	S3SseKms            = "SSE-KMS"
+
+	s3CaBundleDir        = "/s3-ca/"
+	s3CaBundleFile       = "ca-bundle.crt"
+	s3CaBundleVolumeName = "s3-ca-bundle"
+	s3TokenDir           = "/var/run/secrets/sts.amazonaws.com/serviceaccount/"
+	s3TokenFile          = "token"
+	s3TokenVolumeName    = "aws-iam-token"
+	s3DefaultAudience    = "sts.amazonaws.com"
+	s3TokenExpiration    = 86400
+)
+
+// GetBackupDaemonServiceAccountAnnotations returns annotations the backup daemon service account
+// needs for S3 web identity, so the pod identity webhook can recognize it.
+func GetBackupDaemonServiceAccountAnnotations(s3Storage *types.S3Storage) map[string]string {
+	if s3Storage == nil || s3Storage.WebIdentity == nil {
+		return map[string]string{}
+	}
+	return map[string]string{S3RoleArnAnnotation: s3Storage.WebIdentity.RoleArn}
+}
+
+// setS3Settings wires S3 options the backup daemon client reads from env into the main container.
+func setS3Settings(deployment *appsv1.Deployment, s3Storage *types.S3Storage) {
+	if s3Storage == nil {
+		return
+	}
+	if volume, ok := getS3CaBundleVolume(s3Storage.CaBundle); ok {
+		deployment.Spec.Template.Spec.Volumes = append(deployment.Spec.Template.Spec.Volumes, volume)
+		container := mainContainer(deployment)
+		container.VolumeMounts = append(container.VolumeMounts, corev1.VolumeMount{
+			MountPath: s3CaBundleDir,
+			Name:      s3CaBundleVolumeName,
+			ReadOnly:  true,
+		})
+		container.Env = append(container.Env, corev1.EnvVar{
+			Name:  "AWS_CA_BUNDLE",
+			Value: s3CaBundleDir + s3CaBundleFile,
+		})
+	}
+	if s3Storage.WebIdentity != nil {
+		setS3WebIdentity(deployment, s3Storage.WebIdentity)
+	}
+	container := mainContainer(deployment)
+	container.Env = append(container.Env, getS3ClientEnvs(s3Storage)...)
+}
+
+// setS3WebIdentity mounts the projected token itself, so it works without the pod identity webhook,
+// the webhook does not override already present variables.
+func setS3WebIdentity(deployment *appsv1.Deployment, webIdentity *types.S3WebIdentity) {
+	audience := webIdentity.Audience
+	if audience == "" {
+		audience = s3DefaultAudience
+	}
+	expiration := int64(s3TokenExpiration)
+	deployment.Spec.Template.Spec.Volumes = append(deployment.Spec.Template.Spec.Volumes, corev1.Volume{
+		Name: s3TokenVolumeName,
+		VolumeSource: corev1.VolumeSource{
+			Projected: &corev1.ProjectedVolumeSource{
+				Sources: []corev1.VolumeProjection{
+					{
+						ServiceAccountToken: &corev1.ServiceAccountTokenProjection{
+							Audience:          audience,
+							ExpirationSeconds: &expiration,
+							Path:              s3TokenFile,
+						},
+					},
+				},
+			},
+		},
+	})
+	container := mainContainer(deployment)
+	container.VolumeMounts = append(container.VolumeMounts, corev1.VolumeMount{
+		MountPath: s3TokenDir,
+		Name:      s3TokenVolumeName,
+		ReadOnly:  true,
+	})
+	container.Env = append(container.Env,
+		corev1.EnvVar{Name: "AWS_ROLE_ARN", Value: webIdentity.RoleArn},
+		corev1.EnvVar{Name: "AWS_WEB_IDENTITY_TOKEN_FILE", Value: s3TokenDir + s3TokenFile},
+	)
+}
+
+func getS3ClientEnvs(s3Storage *types.S3Storage) []corev1.EnvVar {
+	var envs []corev1.EnvVar
+	if s3Storage.AddressingStyle != "" {
+		envs = append(envs, corev1.EnvVar{Name: "S3_ADDRESSING_STYLE", Value: s3Storage.AddressingStyle})
+	}
+	if sse := s3Storage.ServerSideEncryption; sse != nil && sse.Type != "" {
+		envs = append(envs, corev1.EnvVar{Name: "S3_SSE", Value: sse.Type})
+		if sse.Type == S3SseKms && sse.KmsKeyId != "" {
+			envs = append(envs, corev1.EnvVar{Name: "S3_SSE_KMS_KEY_ID", Value: sse.KmsKeyId})
+		}
+	}
+	if s3Storage.StorageClass != "" {
+		envs = append(envs, corev1.EnvVar{Name: "S3_STORAGE_CLASS", Value: s3Storage.StorageClass})
+	}
+	if lock := s3Storage.ObjectLock; lock != nil {
+		if retention, err := util.ParseAge(lock.Retention); err == nil {
+			envs = append(envs,
+				corev1.EnvVar{Name: "S3_OBJECT_LOCK_MODE", Value: strings.ToUpper(lock.Mode)},
+				corev1.EnvVar{Name: "S3_OBJECT_LOCK_RETENTION_SECONDS", Value: strconv.Itoa(int(retention.Seconds()))},
+			)
+		} else {
+			logger.Error("Skipping S3 object lock settings", zap.Error(err))
+		}
+	}
+	return envs
+}
+
+func getS3CaBundleVolume(caBundle *types.CaBundle) (corev1.Volume, bool) {
+	volume := corev1.Volume{Name: s3CaBundleVolumeName}
+	switch {
+	case caBundle == nil:
+		return volume, false
+	case caBundle.ConfigMap != nil:
+		volume.VolumeSource = corev1.VolumeSource{
+			ConfigMap: &corev1.ConfigMapVolumeSource{
+				LocalObjectReference: caBundle.ConfigMap.LocalObjectReference,
+				Items:                []corev1.KeyToPath{{Key: caBundle.ConfigMap.Key, Path: s3CaBundleFile}},
+			},
+		}
+	case caBundle.Secret != nil:
+		volume.VolumeSource = corev1.VolumeSource{
+			Secret: &corev1.SecretVolumeSource{
+				SecretName: caBundle.Secret.Name,
+				Items:      []corev1.KeyToPath{{Key: caBundle.Secret.Key, Path: s3CaBundleFile}},
+			},
+		}
+	default:
+		return volume, false
+	}
+	return volume, true
+}
@@ -0,0 +1,183 @@
+// Copyright 2024-2025 NetCracker Technology Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build minio
+
+// Object lock settings passed to the daemon are checked against MinIO started with
+//
+//	docker run -p 9000:9000 -e MINIO_ROOT_USER=minioadmin -e MINIO_ROOT_PASSWORD=minioadmin minio/minio server /data
+//	MINIO_ENDPOINT=http://localhost:9000 MINIO_ACCESS_KEY=minioadmin MINIO_SECRET_KEY=minioadmin \
+//	    go test -tags minio ./pkg/reconciler/ -run MinIO
+
+package reconciler
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/md5"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	types "github.com/Netcracker/pgskipper-operator-core/api/v1"
+	"github.com/Netcracker/pgskipper-operator-core/pkg/validation"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+const minioRegion = "us-east-1"
+
+type minioClient struct {
+	endpoint  string
+	accessKey string
+	secretKey string
+}
+
+func TestMinIOObjectLock(t *testing.T) {
+	client := minioClient{
+		endpoint:  os.Getenv("MINIO_ENDPOINT"),
+		accessKey: os.Getenv("MINIO_ACCESS_KEY"),
+		secretKey: os.Getenv("MINIO_SECRET_KEY"),
+	}
+	if client.endpoint == "" {
+		t.Skip("MINIO_ENDPOINT is not set")
+	}
+	bucket := fmt.Sprintf("object-lock-%d", time.Now().Unix())
+	s3Storage := &types.S3Storage{
+		Url:             client.endpoint,
+		AccessKeyId:     client.accessKey,
+		SecretAccessKey: client.secretKey,
+		Bucket:          bucket,
+		ObjectLock:      &types.S3ObjectLock{Mode: "governance", Retention: "1d"},
+	}
+	backupDaemon := &types.BackupDaemon{
+		Storage:        types.Storage{Type: "s3"},
+		S3Storage:      s3Storage,
+		EvictionPolicy: "7d/delete",
+	}
+	if errs := validation.ValidateBackupDaemon(backupDaemon, field.NewPath("spec")); len(errs) > 0 {
+		t.Fatalf("validation: %v", errs)
+	}
+	env := map[string]string{}
+	for _, envVar := range getS3ClientEnvs(s3Storage) {
+		env[envVar.Name] = envVar.Value
+	}
+	retention, err := strconv.Atoi(env["S3_OBJECT_LOCK_RETENTION_SECONDS"])
+	if err != nil {
+		t.Fatalf("retention: %v", err)
+	}
+
+	client.mustDo(t, http.MethodPut, "/"+bucket, nil, map[string]string{"x-amz-bucket-object-lock-enabled": "true"}, http.StatusOK)
+	defer client.mustDo(t, http.MethodDelete, "/"+bucket, nil, nil, http.StatusNoContent)
+
+	// uploaded the way the daemon does it with settings from env
+	body := []byte("backup")
+	digest := md5.Sum(body)
+	response := client.mustDo(t, http.MethodPut, "/"+bucket+"/backup", body, map[string]string{
+		"content-md5":                         base64.StdEncoding.EncodeToString(digest[:]),
+		"x-amz-object-lock-mode":              env["S3_OBJECT_LOCK_MODE"],
+		"x-amz-object-lock-retain-until-date": time.Now().Add(time.Duration(retention) * time.Second).UTC().Format(time.RFC3339),
+	}, http.StatusOK)
+	version := response.Header.Get("x-amz-version-id")
+	if version == "" {
+		t.Fatalf("bucket is not versioned, object lock is not enabled")
+	}
+	object := "/" + bucket + "/backup?versionId=" + url.QueryEscape(version)
+
+	// eviction must not be able to delete a locked backup
+	client.mustDo(t, http.MethodDelete, object, nil, nil, http.StatusForbidden)
+	client.mustDo(t, http.MethodDelete, object, nil, map[string]string{"x-amz-bypass-governance-retention": "true"}, http.StatusNoContent)
+}
+
+func (c minioClient) mustDo(t *testing.T, method string, path string, body []byte, headers map[string]string, status int) *http.Response {
+	t.Helper()
+	request, err := http.NewRequest(method, c.endpoint+path, bytes.NewReader(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for name, value := range headers {
+		request.Header.Set(name, value)
+	}
+	c.sign(request, body, time.Now().UTC())
+	response, err := http.DefaultClient.Do(request)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer response.Body.Close()
+	if response.StatusCode != status {
+		message, _ := io.ReadAll(response.Body)
+		t.Fatalf("%s %s: got %d, expected %d: %s", method, path, response.StatusCode, status, message)
+	}
+	return response
+}
+
+// sign adds AWS signature version 4 headers to request.
+func (c minioClient) sign(request *http.Request, body []byte, now time.Time) {
+	payloadHash := sha256Hex(body)
+	request.Header.Set("x-amz-date", now.Format("20060102T150405Z"))
+	request.Header.Set("x-amz-content-sha256", payloadHash)
+
+	headers := map[string]string{"host": request.URL.Host}
+	for name := range request.Header {
+		headers[strings.ToLower(name)] = strings.TrimSpace(request.Header.Get(name))
+	}
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var canonicalHeaders strings.Builder
+	for _, name := range names {
+		canonicalHeaders.WriteString(name + ":" + headers[name] + "\n")
+	}
+	signedHeaders := strings.Join(names, ";")
+	canonicalRequest := strings.Join([]string{
+		request.Method,
+		request.URL.EscapedPath(),
+		request.URL.Query().Encode(),
+		canonicalHeaders.String(),
+		signedHeaders,
+		payloadHash,
+	}, "\n")
+
+	date := now.Format("20060102")
+	scope := date + "/" + minioRegion + "/s3/aws4_request"
+	stringToSign := strings.Join([]string{"AWS4-HMAC-SHA256", now.Format("20060102T150405Z"), scope, sha256Hex([]byte(canonicalRequest))}, "\n")
+	key := []byte("AWS4" + c.secretKey)
+	for _, part := range []string{date, minioRegion, "s3", "aws4_request"} {
+		key = hmacSha256(key, part)
+	}
+	request.Header.Set("Authorization", fmt.Sprintf("AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		c.accessKey, scope, signedHeaders, hex.EncodeToString(hmacSha256(key, stringToSign))))
+}
+
+func sha256Hex(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+func hmacSha256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
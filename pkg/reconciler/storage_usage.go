@@ -0,0 +1,97 @@
+// Copyright 2024-2025 NetCracker Technology Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reconciler
+
+import (
+	"fmt"
+	"strings"
+
+	types "github.com/Netcracker/pgskipper-operator-core/api/v1"
+	"github.com/Netcracker/pgskipper-operator-core/pkg/util"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// StorageUsagePath of the daemon returns {"usedBytes": n, "quotaBytes": n, "fullBackups": n},
+	// quotaBytes is STORAGE_QUOTA_BYTES and is 0 without size retention.
+	StorageUsagePath        = "/v2/storage/usage"
+	StorageUsageMeasurement = "backup_storage"
+
+	storageUsageCollectorConfig = "postgres-backup-storage-usage.collector-config"
+)
+
+func GetStorageUsageCollectorConfigMapName(instance string) string {
+	return util.InstanceName(storageUsageCollectorConfig, instance)
+}
+
+func ConfigMapForStorageUsageMonitoring(backupDaemon *types.BackupDaemon, configKey string) *corev1.ConfigMap {
+	return ConfigMapForStorageUsageMonitoringForInstance("", backupDaemon, configKey)
+}
+
+func ConfigMapForStorageUsageMonitoringForInstance(instance string, backupDaemon *types.BackupDaemon, configKey string) *corev1.ConfigMap {
+	return ConfigMapForStorageUsageMonitoringInScope(Scope{Instance: instance}, backupDaemon, configKey)
+}
+
+// ConfigMapForStorageUsageMonitoringInScope builds telegraf input collecting used bytes and quota of
+// the backup storage from the daemon, so usage can be alerted on before size retention starts evicting.
+func ConfigMapForStorageUsageMonitoringInScope(scope Scope, backupDaemon *types.BackupDaemon, configKey string) *corev1.ConfigMap {
+	return &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      GetStorageUsageCollectorConfigMapName(scope.Instance),
+			Namespace: scope.GetNamespace(),
+			Labels:    GetMetricCollectorLabels(scope.Instance),
+		},
+		Data: map[string]string{
+			configKey: getStorageUsageTelegrafConfig(scope, backupDaemon),
+		},
+	}
+}
+
+func getStorageUsageTelegrafConfig(scope Scope, backupDaemon *types.BackupDaemon) string {
+	url := fmt.Sprintf("%s://%s.%s.svc:8080%s", strings.ToLower(string(getBackupDaemonScheme(backupDaemon.Tls))),
+		GetBackupDaemonName(scope.Instance), scope.GetNamespace(), StorageUsagePath)
+	lines := []string{
+		"[[inputs.http]]",
+		fmt.Sprintf("  urls = [%q]", url),
+		`  method = "GET"`,
+		`  timeout = "10s"`,
+		fmt.Sprintf("  name_override = %q", StorageUsageMeasurement),
+		`  data_format = "json"`,
+	}
+	if isTlsEnabled(backupDaemon.Tls) {
+		// the collector does not mount the daemon CA
+		lines = append(lines, "  insecure_skip_verify = true")
+	}
+	// credentials are expanded by telegraf from GetBackupDaemonAuthEnvs of the collector
+	if auth := backupDaemon.Auth; auth != nil && auth.Enabled {
+		if getBackupDaemonAuthType(auth) == BackupDaemonAuthToken {
+			lines = append(lines, `  headers = {"Authorization" = "Bearer ${BACKUP_DAEMON_AUTH_TOKEN}"}`)
+		} else {
+			lines = append(lines,
+				`  username = "${BACKUP_DAEMON_AUTH_USERNAME}"`,
+				`  password = "${BACKUP_DAEMON_AUTH_PASSWORD}"`,
+			)
+		}
+	}
+	lines = append(lines,
+		"  [inputs.http.tags]",
+		fmt.Sprintf("    storage_type = %q", backupDaemon.Storage.Type),
+	)
+	if scope.Instance != "" {
+		lines = append(lines, fmt.Sprintf("    instance = %q", util.DNSName(scope.Instance)))
+	}
+	return strings.Join(lines, "\n") + "\n"
+}
@@ -0,0 +1,82 @@
+// Copyright 2024-2025 NetCracker Technology Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reconciler
+
+import (
+	"strings"
+	"testing"
+
+	types "github.com/Netcracker/pgskipper-operator-core/api/v1"
+)
+
+func TestConfigMapForStorageUsageMonitoring(t *testing.T) {
+	tests := map[string]struct {
+		scope    Scope
+		daemon   types.BackupDaemon
+		expected []string
+		absent   []string
+	}{
+		"legacy": {
+			scope:  Scope{Namespace: "pg"},
+			daemon: types.BackupDaemon{Storage: types.Storage{Type: "provisioned"}},
+			expected: []string{
+				"[[inputs.http]]",
+				`urls = ["http://postgres-backup-daemon.pg.svc:8080/v2/storage/usage"]`,
+				`name_override = "backup_storage"`,
+				`data_format = "json"`,
+				`storage_type = "provisioned"`,
+			},
+			absent: []string{"instance =", "insecure_skip_verify", "username", "headers"},
+		},
+		"instance with tls and basic auth": {
+			scope: Scope{Namespace: "pg", Instance: "billing"},
+			daemon: types.BackupDaemon{
+				Tls:  &types.BackupDaemonTls{Enabled: true},
+				Auth: &types.BackupDaemonAuth{Enabled: true},
+			},
+			expected: []string{
+				`urls = ["https://postgres-backup-daemon-billing.pg.svc:8080/v2/storage/usage"]`,
+				"insecure_skip_verify = true",
+				`username = "${BACKUP_DAEMON_AUTH_USERNAME}"`,
+				`instance = "billing"`,
+			},
+		},
+		"token auth": {
+			scope:    Scope{Namespace: "pg"},
+			daemon:   types.BackupDaemon{Auth: &types.BackupDaemonAuth{Enabled: true, Type: "token"}},
+			expected: []string{`headers = {"Authorization" = "Bearer ${BACKUP_DAEMON_AUTH_TOKEN}"}`},
+			absent:   []string{"username"},
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			configMap := ConfigMapForStorageUsageMonitoringInScope(test.scope, &test.daemon, "telegraf.conf")
+			if configMap.Name != GetStorageUsageCollectorConfigMapName(test.scope.Instance) || configMap.Namespace != "pg" {
+				t.Errorf("unexpected config map %s/%s", configMap.Namespace, configMap.Name)
+			}
+			config := configMap.Data["telegraf.conf"]
+			for _, line := range test.expected {
+				if !strings.Contains(config, line) {
+					t.Errorf("config has no %q:\n%s", line, config)
+				}
+			}
+			for _, line := range test.absent {
+				if strings.Contains(config, line) {
+					t.Errorf("config must not have %q:\n%s", line, config)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,75 @@
+// Copyright 2024-2025 NetCracker Technology Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reconciler
+
+import (
+	"strconv"
+
+	types "github.com/Netcracker/pgskipper-operator-core/api/v1"
+	"github.com/Netcracker/pgskipper-operator-core/pkg/util"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+// BlockIoClassAnnotation selects the block IO class of the pod in containerd and CRI-O.
+const BlockIoClassAnnotation = "blockio.resources.beta.kubernetes.io/pod"
+
+// setThrottling passes throttling settings to the daemon. Rates are passed in bytes per second.
+func setThrottling(deployment *appsv1.Deployment, throttling *types.BackupThrottling) {
+	if throttling == nil {
+		return
+	}
+	var env []corev1.EnvVar
+	if rate, ok := getBytesPerSecond(throttling.UploadBandwidth); ok {
+		env = append(env, corev1.EnvVar{Name: "S3_MAX_BANDWIDTH", Value: rate})
+	}
+	if rate, ok := getBytesPerSecond(throttling.ReadRate); ok {
+		env = append(env, corev1.EnvVar{Name: "PG_MAX_RATE", Value: rate})
+	}
+	if throttling.GranularConcurrency > 0 {
+		env = append(env, corev1.EnvVar{
+			Name:  "GRANULAR_DUMP_CONCURRENCY",
+			Value: strconv.Itoa(throttling.GranularConcurrency),
+		})
+	}
+	if priority := throttling.IoPriority; priority != nil {
+		if priority.Class != "" {
+			env = append(env, corev1.EnvVar{Name: "IONICE_CLASS", Value: priority.Class})
+		}
+		if priority.Level != nil {
+			env = append(env, corev1.EnvVar{Name: "IONICE_LEVEL", Value: strconv.Itoa(*priority.Level)})
+		}
+		if priority.BlockIoClass != "" {
+			template := &deployment.Spec.Template
+			template.Annotations = util.Merge(template.Annotations, map[string]string{
+				BlockIoClassAnnotation: priority.BlockIoClass,
+			})
+		}
+	}
+	container := mainContainer(deployment)
+	container.Env = append(container.Env, env...)
+}
+
+func getBytesPerSecond(rate string) (string, bool) {
+	if rate == "" {
+		return "", false
+	}
+	quantity, err := resource.ParseQuantity(rate)
+	if err != nil {
+		return "", false
+	}
+	return strconv.FormatInt(quantity.Value(), 10), true
+}
@@ -0,0 +1,222 @@
+// Copyright 2024-2025 NetCracker Technology Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reconciler
+
+import (
+	"fmt"
+
+	types "github.com/Netcracker/pgskipper-operator-core/api/v1"
+	"github.com/Netcracker/pgskipper-operator-core/pkg/util"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+const (
+	BackupDaemonTlsSecret = "postgres-backup-daemon-tls"
+	TlsAnnotation         = "qubership.org/tls"
+	TlsSecretAnnotation   = "qubership.org/tls-secret"
+
+	certManagerGroup   = "cert-manager.io"
+	backupDaemonTlsDir = "/certs/"
+	tlsVolumeName      = "backup-daemon-tls"
+	pgSslDir           = "/pgssl/"
+	pgSslVolumeName    = "pg-ssl"
+	pgSslCa            = "ca.crt"
+	pgSslCert          = "client.crt"
+	pgSslKey           = "client.key"
+)
+
+func isTlsEnabled(tls *types.BackupDaemonTls) bool {
+	return tls != nil && tls.Enabled
+}
+
+func GetBackupDaemonTlsSecretName(instance string, tls *types.BackupDaemonTls) string {
+	if tls != nil && tls.SecretName != "" {
+		return tls.SecretName
+	}
+	return util.InstanceName(BackupDaemonTlsSecret, instance)
+}
+
+// NewBackupDaemonCertificate builds cert-manager Certificate for the daemon Service.
+// Nil is returned when TLS is disabled or an existing Secret is used.
+func NewBackupDaemonCertificate(scope Scope, tls *types.BackupDaemonTls) *unstructured.Unstructured {
+	if !isTlsEnabled(tls) || tls.SecretName != "" || tls.Issuer == nil {
+		return nil
+	}
+	namespace := scope.GetNamespace()
+	service := GetBackupDaemonName(scope.Instance)
+	dnsNames := []interface{}{
+		service,
+		fmt.Sprintf("%s.%s", service, namespace),
+		fmt.Sprintf("%s.%s.svc", service, namespace),
+		fmt.Sprintf("%s.%s.svc.cluster.local", service, namespace),
+	}
+	for _, name := range tls.DnsNames {
+		dnsNames = append(dnsNames, name)
+	}
+	issuerKind := tls.Issuer.Kind
+	if issuerKind == "" {
+		issuerKind = "Issuer"
+	}
+	issuerGroup := tls.Issuer.Group
+	if issuerGroup == "" {
+		issuerGroup = certManagerGroup
+	}
+	certificate := &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"spec": map[string]interface{}{
+				"secretName": GetBackupDaemonTlsSecretName(scope.Instance, tls),
+				"commonName": service,
+				"dnsNames":   dnsNames,
+				"usages":     []interface{}{"server auth", "digital signature", "key encipherment"},
+				"issuerRef": map[string]interface{}{
+					"name":  tls.Issuer.Name,
+					"kind":  issuerKind,
+					"group": issuerGroup,
+				},
+			},
+		},
+	}
+	certificate.SetAPIVersion(certManagerGroup + "/v1")
+	certificate.SetKind("Certificate")
+	certificate.SetName(GetBackupDaemonTlsSecretName(scope.Instance, tls))
+	certificate.SetNamespace(namespace)
+	certificate.SetLabels(GetBackupDaemonLabels(scope.Instance))
+	return certificate
+}
+
+// GetBackupDaemonServiceAnnotations tells clients of the daemon Service which scheme to use
+// and which Secret holds the CA of the daemon certificate.
+func GetBackupDaemonServiceAnnotations(instance string, tls *types.BackupDaemonTls) map[string]string {
+	if !isTlsEnabled(tls) {
+		return map[string]string{}
+	}
+	return map[string]string{
+		TlsAnnotation:       "true",
+		TlsSecretAnnotation: GetBackupDaemonTlsSecretName(instance, tls),
+	}
+}
+
+// GetPortsForBackupServiceWithTls marks the daemon ports with https application protocol when TLS is enabled.
+func GetPortsForBackupServiceWithTls(tls *types.BackupDaemonTls) []corev1.ServicePort {
+	ports := GetPortsForBackupService()
+	if isTlsEnabled(tls) {
+		appProtocol := "https"
+		for i := range ports {
+			ports[i].AppProtocol = &appProtocol
+		}
+	}
+	return ports
+}
+
+func getBackupDaemonScheme(tls *types.BackupDaemonTls) corev1.URIScheme {
+	if isTlsEnabled(tls) {
+		return corev1.URISchemeHTTPS
+	}
+	return corev1.URISchemeHTTP
+}
+
+func getBackupDaemonTlsEnvs(tls *types.BackupDaemonTls) []corev1.EnvVar {
+	if !isTlsEnabled(tls) {
+		return []corev1.EnvVar{}
+	}
+	return []corev1.EnvVar{
+		{
+			Name:  "TLS",
+			Value: "true",
+		},
+		{
+			Name:  "CERTIFICATE_FILE_PATH",
+			Value: backupDaemonTlsDir + corev1.TLSCertKey,
+		},
+		{
+			Name:  "PRIVATE_KEY_FILE_PATH",
+			Value: backupDaemonTlsDir + corev1.TLSPrivateKeyKey,
+		},
+		{
+			Name:  "CA_FILE_PATH",
+			Value: backupDaemonTlsDir + "ca.crt",
+		},
+	}
+}
+
+func getBackupDaemonTlsVolume(instance string, tls *types.BackupDaemonTls) corev1.Volume {
+	return corev1.Volume{
+		Name: tlsVolumeName,
+		VolumeSource: corev1.VolumeSource{
+			Secret: &corev1.SecretVolumeSource{
+				SecretName: GetBackupDaemonTlsSecretName(instance, tls),
+			},
+		},
+	}
+}
+
+func getBackupDaemonTlsVolumeMount() corev1.VolumeMount {
+	return corev1.VolumeMount{
+		MountPath: backupDaemonTlsDir,
+		Name:      tlsVolumeName,
+		ReadOnly:  true,
+	}
+}
+
+func isPgSslConfigured(ssl *types.PgSsl) bool {
+	return ssl != nil && (ssl.Ca != nil || ssl.Cert != nil || ssl.Key != nil)
+}
+
+// setPgSsl mounts Postgres client SSL files into the main container and points libpq to them.
+func setPgSsl(deployment *appsv1.Deployment, ssl *types.PgSsl) {
+	if !isPgSslConfigured(ssl) {
+		return
+	}
+	// libpq refuses a key readable by others
+	keyMode := int32(0640)
+	volume := corev1.Volume{
+		Name: pgSslVolumeName,
+		VolumeSource: corev1.VolumeSource{
+			Projected: &corev1.ProjectedVolumeSource{},
+		},
+	}
+	var envs []corev1.EnvVar
+	for _, file := range []struct {
+		selector *corev1.SecretKeySelector
+		path     string
+		env      string
+		mode     *int32
+	}{
+		{ssl.Ca, pgSslCa, "PGSSLROOTCERT", nil},
+		{ssl.Cert, pgSslCert, "PGSSLCERT", nil},
+		{ssl.Key, pgSslKey, "PGSSLKEY", &keyMode},
+	} {
+		if file.selector == nil {
+			continue
+		}
+		volume.Projected.Sources = append(volume.Projected.Sources, corev1.VolumeProjection{
+			Secret: &corev1.SecretProjection{
+				LocalObjectReference: file.selector.LocalObjectReference,
+				Items:                []corev1.KeyToPath{{Key: file.selector.Key, Path: file.path, Mode: file.mode}},
+			},
+		})
+		envs = append(envs, corev1.EnvVar{Name: file.env, Value: pgSslDir + file.path})
+	}
+	deployment.Spec.Template.Spec.Volumes = append(deployment.Spec.Template.Spec.Volumes, volume)
+	container := mainContainer(deployment)
+	container.VolumeMounts = append(container.VolumeMounts, corev1.VolumeMount{
+		MountPath: pgSslDir,
+		Name:      pgSslVolumeName,
+		ReadOnly:  true,
+	})
+	container.Env = append(container.Env, envs...)
+}
@@ -0,0 +1,114 @@
+// Copyright 2024-2025 NetCracker Technology Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package schedule validates backup daemon cron schedules and previews their runs.
+package schedule
+
+import (
+	"fmt"
+	"strings"
+	"time"
+	// timezones are resolved in images without zoneinfo as well
+	_ "time/tzdata"
+
+	"github.com/robfig/cron/v3"
+)
+
+const (
+	// None disables scheduled backups in the backup daemon, in any case
+	None = "None"
+
+	// overlapSamples is the number of runs checked for overlap with the backup timeout
+	overlapSamples = 100
+)
+
+type Schedule struct {
+	spec     string
+	location *time.Location
+	schedule cron.Schedule
+}
+
+// Parse parses a standard five field cron spec or a descriptor such as "@daily"
+// in timezone, empty timezone is UTC. None and empty spec give a disabled schedule.
+func Parse(spec string, timezone string) (*Schedule, error) {
+	location, err := time.LoadLocation(timezone)
+	if err != nil {
+		return nil, fmt.Errorf("invalid timezone %q: %w", timezone, err)
+	}
+	result := &Schedule{spec: spec, location: location}
+	if result.Disabled() {
+		return result, nil
+	}
+	if strings.HasPrefix(spec, "TZ=") || strings.HasPrefix(spec, "CRON_TZ=") {
+		return nil, fmt.Errorf("invalid schedule %q, use timezone field instead of TZ prefix", spec)
+	}
+	result.schedule, err = cron.ParseStandard(fmt.Sprintf("CRON_TZ=%s %s", location, spec))
+	if err != nil {
+		return nil, fmt.Errorf("invalid schedule %q: %w", spec, err)
+	}
+	return result, nil
+}
+
+func (s *Schedule) Disabled() bool {
+	return s.spec == "" || strings.EqualFold(s.spec, None)
+}
+
+func (s *Schedule) String() string {
+	return s.spec
+}
+
+func (s *Schedule) Location() *time.Location {
+	return s.location
+}
+
+// Next returns up to n runs after from, in the schedule timezone.
+func (s *Schedule) Next(from time.Time, n int) []time.Time {
+	if s.Disabled() {
+		return nil
+	}
+	runs := make([]time.Time, 0, n)
+	next := from
+	for i := 0; i < n; i++ {
+		next = s.schedule.Next(next)
+		if next.IsZero() {
+			break
+		}
+		runs = append(runs, next.In(s.location))
+	}
+	return runs
+}
+
+// MinInterval returns the shortest gap between runs among the next samples runs after from.
+func (s *Schedule) MinInterval(from time.Time, samples int) time.Duration {
+	runs := s.Next(from, samples)
+	var minInterval time.Duration
+	for i := 1; i < len(runs); i++ {
+		interval := runs[i].Sub(runs[i-1])
+		if minInterval == 0 || interval < minInterval {
+			minInterval = interval
+		}
+	}
+	return minInterval
+}
+
+// CheckTimeout returns an error when a backup may still run with timeout when the next one starts.
+func (s *Schedule) CheckTimeout(from time.Time, timeout time.Duration) error {
+	if s.Disabled() || timeout <= 0 {
+		return nil
+	}
+	if interval := s.MinInterval(from, overlapSamples); interval > 0 && interval < timeout {
+		return fmt.Errorf("schedule %q runs every %s, more frequent than backup timeout %s", s.spec, interval, timeout)
+	}
+	return nil
+}
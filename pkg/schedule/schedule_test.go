@@ -0,0 +1,85 @@
+// Copyright 2024-2025 NetCracker Technology Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package schedule
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParse(t *testing.T) {
+	for _, spec := range []string{"", "None", "0 1 * * *", "*/15 * * * 1-5", "@daily"} {
+		if _, err := Parse(spec, "Europe/Berlin"); err != nil {
+			t.Errorf("Parse(%q): %v", spec, err)
+		}
+	}
+	for _, spec := range []string{"None", "none", "NONE", ""} {
+		if schedule := mustParse(t, spec); !schedule.Disabled() || schedule.Next(at("00:00"), 1) != nil {
+			t.Errorf("Parse(%q) must be disabled", spec)
+		}
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	tests := map[string]string{
+		"61 * * * *":            "UTC",
+		"0 1 * *":               "UTC",
+		"TZ=UTC 0 1 * * *":      "UTC",
+		"CRON_TZ=UTC 0 1 * * *": "UTC",
+		"0 1 * * *":             "Mars/Olympus",
+	}
+	for spec, timezone := range tests {
+		if _, err := Parse(spec, timezone); err == nil {
+			t.Errorf("Parse(%q, %q) expected error", spec, timezone)
+		}
+	}
+}
+
+func TestNext(t *testing.T) {
+	schedule, err := Parse("0 1 * * *", "Europe/Berlin")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// 01:00 in Berlin is 00:00 UTC in winter, runs are strictly after from
+	runs := schedule.Next(at("00:00"), 2)
+	expected := []time.Time{at("00:00").AddDate(0, 0, 1), at("00:00").AddDate(0, 0, 2)}
+	if len(runs) != len(expected) {
+		t.Fatalf("Next() = %v", runs)
+	}
+	for i, run := range runs {
+		if !run.Equal(expected[i]) || run.Location() != schedule.Location() {
+			t.Errorf("run %d = %s, expected %s in %s", i, run, expected[i], schedule.Location())
+		}
+	}
+}
+
+func TestCheckTimeout(t *testing.T) {
+	tests := []struct {
+		spec    string
+		timeout time.Duration
+		err     bool
+	}{
+		{"*/30 * * * *", time.Hour, true},
+		{"*/30 * * * *", 10 * time.Minute, false},
+		{"0 1,2 * * *", 2 * time.Hour, true},
+		{"0 1 * * *", 0, false},
+		{"None", time.Hour, false},
+	}
+	for _, test := range tests {
+		if err := mustParse(t, test.spec).CheckTimeout(at("00:00"), test.timeout); (err != nil) != test.err {
+			t.Errorf("CheckTimeout(%q, %s) = %v", test.spec, test.timeout, err)
+		}
+	}
+}
@@ -0,0 +1,273 @@
+// Copyright 2024-2025 NetCracker Technology Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package schedule
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	types "github.com/Netcracker/pgskipper-operator-core/api/v1"
+	"github.com/Netcracker/pgskipper-operator-core/pkg/util"
+)
+
+const (
+	PolicyDefer = "defer"
+	PolicySkip  = "skip"
+
+	clockLayout = "15:04"
+	// maxDeferSteps bounds the search of allowed time when windows and blackouts chain
+	maxDeferSteps = 100
+)
+
+// Run is a scheduled backup and the time it effectively starts after windows are applied.
+type Run struct {
+	Scheduled time.Time
+	Effective time.Time
+	Skipped   bool
+}
+
+type Windows struct {
+	allowed   []window
+	blackouts []blackout
+	policy    string
+	location  *time.Location
+}
+
+type window struct {
+	days  map[time.Weekday]bool
+	start time.Duration
+	end   time.Duration
+}
+
+type blackout struct {
+	schedule *Schedule
+	duration time.Duration
+	from     time.Time
+	to       time.Time
+}
+
+// ParseWindows parses backup windows in timezone. Nil spec allows backups any time.
+func ParseWindows(spec *types.BackupWindows, timezone string) (*Windows, error) {
+	location, err := time.LoadLocation(timezone)
+	if err != nil {
+		return nil, fmt.Errorf("invalid timezone %q: %w", timezone, err)
+	}
+	result := &Windows{policy: PolicyDefer, location: location}
+	if spec == nil {
+		return result, nil
+	}
+	switch spec.Policy {
+	case "", PolicyDefer:
+	case PolicySkip:
+		result.policy = PolicySkip
+	default:
+		return nil, fmt.Errorf("invalid policy %q, must be %s or %s", spec.Policy, PolicyDefer, PolicySkip)
+	}
+	for _, allowed := range spec.Allowed {
+		parsed, err := parseWindow(allowed)
+		if err != nil {
+			return nil, err
+		}
+		result.allowed = append(result.allowed, parsed)
+	}
+	for _, period := range spec.Blackouts {
+		parsed, err := parseBlackout(period, timezone)
+		if err != nil {
+			return nil, err
+		}
+		result.blackouts = append(result.blackouts, parsed)
+	}
+	return result, nil
+}
+
+func parseWindow(spec types.TimeWindow) (window, error) {
+	result := window{days: map[time.Weekday]bool{}}
+	for _, day := range spec.Days {
+		weekday, ok := parseWeekday(day)
+		if !ok {
+			return result, fmt.Errorf("invalid day %q", day)
+		}
+		result.days[weekday] = true
+	}
+	var err error
+	if result.start, err = parseClock(spec.Start); err != nil {
+		return result, err
+	}
+	if result.end, err = parseClock(spec.End); err != nil {
+		return result, err
+	}
+	if result.start == result.end {
+		return result, fmt.Errorf("window %s-%s is empty", spec.Start, spec.End)
+	}
+	return result, nil
+}
+
+// parseWeekday accepts full weekday names and their three letter abbreviations in any case.
+func parseWeekday(day string) (time.Weekday, bool) {
+	lower := strings.ToLower(day)
+	for weekday := time.Sunday; weekday <= time.Saturday; weekday++ {
+		name := strings.ToLower(weekday.String())
+		if lower == name || lower == name[:3] {
+			return weekday, true
+		}
+	}
+	return 0, false
+}
+
+func parseClock(clock string) (time.Duration, error) {
+	parsed, err := time.Parse(clockLayout, clock)
+	if err != nil {
+		return 0, fmt.Errorf("invalid time %q, expected HH:MM", clock)
+	}
+	return time.Duration(parsed.Hour())*time.Hour + time.Duration(parsed.Minute())*time.Minute, nil
+}
+
+func parseBlackout(spec types.BlackoutPeriod, timezone string) (blackout, error) {
+	result := blackout{}
+	switch {
+	case spec.Schedule != "" && (spec.From != nil || spec.To != nil):
+		return result, fmt.Errorf("blackout %q must be either recurring or one-off", spec.Name)
+	case spec.Schedule != "":
+		schedule, err := Parse(spec.Schedule, timezone)
+		if err != nil || schedule.Disabled() {
+			return result, fmt.Errorf("blackout %q has invalid schedule %q", spec.Name, spec.Schedule)
+		}
+		duration, err := util.ParseAge(spec.Duration)
+		if err != nil || duration <= 0 {
+			return result, fmt.Errorf("blackout %q has invalid duration %q", spec.Name, spec.Duration)
+		}
+		result.schedule = schedule
+		result.duration = duration
+	case spec.From != nil && spec.To != nil:
+		if !spec.To.After(spec.From.Time) {
+			return result, fmt.Errorf("blackout %q ends before it starts", spec.Name)
+		}
+		result.from = spec.From.Time
+		result.to = spec.To.Time
+	default:
+		return result, fmt.Errorf("blackout %q needs schedule and duration, or from and to", spec.Name)
+	}
+	return result, nil
+}
+
+// Allowed reports whether a backup may start at t.
+func (w *Windows) Allowed(t time.Time) bool {
+	_, blocked := w.blockedUntil(t)
+	return !blocked
+}
+
+// NextAllowed returns the earliest time not before t when a backup may start.
+func (w *Windows) NextAllowed(t time.Time) (time.Time, bool) {
+	for i := 0; i < maxDeferSteps; i++ {
+		until, blocked := w.blockedUntil(t)
+		if !blocked {
+			return t, true
+		}
+		t = until
+	}
+	return time.Time{}, false
+}
+
+// blockedUntil returns the time a blackout ends or the next window opens when t is not allowed.
+func (w *Windows) blockedUntil(t time.Time) (time.Time, bool) {
+	for _, period := range w.blackouts {
+		if end, ok := period.endIfActive(t); ok {
+			return end, true
+		}
+	}
+	if len(w.allowed) == 0 {
+		return t, false
+	}
+	local := t.In(w.location)
+	var next time.Time
+	for _, allowed := range w.allowed {
+		if allowed.contains(local) {
+			return t, false
+		}
+		if start := allowed.nextStart(local); next.IsZero() || start.Before(next) {
+			next = start
+		}
+	}
+	return next, true
+}
+
+func (b blackout) endIfActive(t time.Time) (time.Time, bool) {
+	if b.schedule == nil {
+		return b.to, !t.Before(b.from) && t.Before(b.to)
+	}
+	// the first start after t - duration is the only one which may still be active at t,
+	// a blackout is over at its end, so a backup may start right then
+	starts := b.schedule.Next(t.Add(-b.duration), 1)
+	if len(starts) == 0 || starts[0].After(t) {
+		return time.Time{}, false
+	}
+	end := starts[0].Add(b.duration)
+	return end, t.Before(end)
+}
+
+func (w window) allowsDay(day time.Weekday) bool {
+	return len(w.days) == 0 || w.days[day]
+}
+
+// contains compares wall clock time, as time since midnight is off by an hour on DST days.
+func (w window) contains(t time.Time) bool {
+	clock := time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute + time.Duration(t.Second())*time.Second
+	if w.start < w.end {
+		return w.allowsDay(t.Weekday()) && clock >= w.start && clock < w.end
+	}
+	// window spans midnight, it belongs to the day it starts
+	if clock >= w.start {
+		return w.allowsDay(t.Weekday())
+	}
+	return clock < w.end && w.allowsDay(t.AddDate(0, 0, -1).Weekday())
+}
+
+func (w window) nextStart(t time.Time) time.Time {
+	hour, minute := int(w.start/time.Hour), int(w.start%time.Hour/time.Minute)
+	for day := 0; day <= 7; day++ {
+		start := time.Date(t.Year(), t.Month(), t.Day()+day, hour, minute, 0, 0, t.Location())
+		if start.After(t) && w.allowsDay(start.Weekday()) {
+			return start
+		}
+	}
+	return t
+}
+
+// EffectiveRuns returns the next n scheduled runs after from with windows applied. A run which is
+// deferred past the next scheduled run is skipped, as the next run takes its place.
+func EffectiveRuns(schedule *Schedule, windows *Windows, from time.Time, n int) []Run {
+	scheduled := schedule.Next(from, n+1)
+	runs := make([]Run, 0, n)
+	for i := 0; i < len(scheduled) && i < n; i++ {
+		run := Run{Scheduled: scheduled[i], Effective: scheduled[i]}
+		if !windows.Allowed(run.Scheduled) {
+			effective, ok := windows.NextAllowed(run.Scheduled)
+			switch {
+			case windows.policy == PolicySkip || !ok:
+				run.Skipped = true
+			case i+1 < len(scheduled) && !effective.Before(scheduled[i+1]):
+				run.Skipped = true
+			default:
+				run.Effective = effective.In(schedule.Location())
+			}
+		}
+		if run.Skipped {
+			run.Effective = time.Time{}
+		}
+		runs = append(runs, run)
+	}
+	return runs
+}
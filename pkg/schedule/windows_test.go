@@ -0,0 +1,233 @@
+// Copyright 2024-2025 NetCracker Technology Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package schedule
+
+import (
+	"testing"
+	"time"
+
+	types "github.com/Netcracker/pgskipper-operator-core/api/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func at(clock string) time.Time {
+	t, err := time.Parse(time.RFC3339, "2025-03-03T"+clock+":00Z")
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
+
+func mustParse(t *testing.T, spec string) *Schedule {
+	t.Helper()
+	schedule, err := Parse(spec, "UTC")
+	if err != nil {
+		t.Fatalf("Parse(%q): %v", spec, err)
+	}
+	return schedule
+}
+
+func mustParseWindows(t *testing.T, spec *types.BackupWindows) *Windows {
+	t.Helper()
+	windows, err := ParseWindows(spec, "UTC")
+	if err != nil {
+		t.Fatalf("ParseWindows: %v", err)
+	}
+	return windows
+}
+
+func TestParseWindowsErrors(t *testing.T) {
+	from := metav1.NewTime(at("10:00"))
+	to := metav1.NewTime(at("08:00"))
+	tests := map[string]types.BackupWindows{
+		"policy":                 {Policy: "later"},
+		"non ascii day":          {Allowed: []types.TimeWindow{{Days: []string{"ẞ"}, Start: "01:00", End: "02:00"}}},
+		"day with valid prefix":  {Allowed: []types.TimeWindow{{Days: []string{"Monkey"}, Start: "01:00", End: "02:00"}}},
+		"short day":              {Allowed: []types.TimeWindow{{Days: []string{"mo"}, Start: "01:00", End: "02:00"}}},
+		"clock":                  {Allowed: []types.TimeWindow{{Start: "1am", End: "02:00"}}},
+		"empty window":           {Allowed: []types.TimeWindow{{Start: "02:00", End: "02:00"}}},
+		"recurring and one-off":  {Blackouts: []types.BlackoutPeriod{{Schedule: "@daily", Duration: "1h", From: &from, To: &to}}},
+		"blackout duration":      {Blackouts: []types.BlackoutPeriod{{Schedule: "@daily", Duration: "soon"}}},
+		"blackout schedule":      {Blackouts: []types.BlackoutPeriod{{Schedule: "None", Duration: "1h"}}},
+		"blackout ends early":    {Blackouts: []types.BlackoutPeriod{{From: &from, To: &to}}},
+		"blackout without times": {Blackouts: []types.BlackoutPeriod{{Name: "empty"}}},
+	}
+	for name, spec := range tests {
+		t.Run(name, func(t *testing.T) {
+			if _, err := ParseWindows(&spec, "UTC"); err == nil {
+				t.Errorf("expected error")
+			}
+		})
+	}
+}
+
+func TestParseWeekday(t *testing.T) {
+	for _, day := range []string{"mon", "Mon", "MONDAY", "monday"} {
+		if weekday, ok := parseWeekday(day); !ok || weekday != time.Monday {
+			t.Errorf("parseWeekday(%q) = %v, %v", day, weekday, ok)
+		}
+	}
+}
+
+func TestAllowed(t *testing.T) {
+	// 2025-03-03 is Monday
+	windows := mustParseWindows(t, &types.BackupWindows{
+		Allowed: []types.TimeWindow{
+			{Days: []string{"mon"}, Start: "22:00", End: "04:00"},
+			{Days: []string{"tue"}, Start: "12:00", End: "13:00"},
+		},
+	})
+	tests := []struct {
+		time    time.Time
+		allowed bool
+	}{
+		{at("21:59"), false},
+		{at("22:00"), true},
+		{at("22:00").Add(5 * time.Hour), true},
+		{at("22:00").Add(6 * time.Hour), false},
+		{at("12:30"), false},
+		{at("12:30").AddDate(0, 0, 1), true},
+		{at("02:00"), false},
+	}
+	for _, test := range tests {
+		if allowed := windows.Allowed(test.time); allowed != test.allowed {
+			t.Errorf("Allowed(%s) = %v, expected %v", test.time, allowed, test.allowed)
+		}
+	}
+}
+
+func TestWindowsAcrossDst(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Fatal(err)
+	}
+	windows, err := ParseWindows(&types.BackupWindows{
+		Allowed: []types.TimeWindow{{Start: "01:00", End: "04:00"}, {Start: "22:00", End: "23:00"}},
+	}, "Europe/Berlin")
+	if err != nil {
+		t.Fatal(err)
+	}
+	local := func(date string, hour int, minute int) time.Time {
+		parsed, err := time.ParseInLocation("2006-01-02", date, berlin)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return time.Date(parsed.Year(), parsed.Month(), parsed.Day(), hour, minute, 0, 0, berlin)
+	}
+	tests := []struct {
+		time    time.Time
+		allowed bool
+	}{
+		// clocks move from 02:00 to 03:00 on 2025-03-30
+		{local("2025-03-30", 0, 30), false},
+		{local("2025-03-30", 1, 30), true},
+		{local("2025-03-30", 3, 30), true},
+		{local("2025-03-30", 4, 30), false},
+		{local("2025-03-30", 22, 30), true},
+		// and from 03:00 back to 02:00 on 2025-10-26
+		{local("2025-10-26", 3, 59), true},
+		{local("2025-10-26", 4, 0), false},
+		{local("2025-10-26", 21, 59), false},
+		{local("2025-10-26", 22, 30), true},
+	}
+	for _, test := range tests {
+		if allowed := windows.Allowed(test.time); allowed != test.allowed {
+			t.Errorf("Allowed(%s) = %v, expected %v", test.time, allowed, test.allowed)
+		}
+	}
+	if next, ok := windows.NextAllowed(local("2025-10-26", 5, 0)); !ok || !next.Equal(local("2025-10-26", 22, 0)) {
+		t.Errorf("NextAllowed = %s, %v, expected 22:00 CET", next, ok)
+	}
+	if next, ok := windows.NextAllowed(local("2025-03-30", 4, 30)); !ok || !next.Equal(local("2025-03-30", 22, 0)) {
+		t.Errorf("NextAllowed = %s, %v, expected 22:00 CEST", next, ok)
+	}
+}
+
+func TestBlackoutEndAllowsBackup(t *testing.T) {
+	windows := mustParseWindows(t, &types.BackupWindows{
+		Blackouts: []types.BlackoutPeriod{{Name: "nightly", Schedule: "0 0 * * *", Duration: "1h"}},
+	})
+	if !windows.Allowed(at("01:00")) {
+		t.Errorf("backup must be allowed when blackout ends")
+	}
+	if windows.Allowed(at("00:00")) || windows.Allowed(at("00:59")) {
+		t.Errorf("backup must not be allowed during blackout")
+	}
+	if next, ok := windows.NextAllowed(at("00:30")); !ok || !next.Equal(at("01:00")) {
+		t.Errorf("NextAllowed(00:30) = %s, %v, expected 01:00", next, ok)
+	}
+	for _, run := range EffectiveRuns(mustParse(t, "0 1 * * *"), windows, at("00:00"), 3) {
+		if run.Skipped || !run.Effective.Equal(run.Scheduled) {
+			t.Errorf("run %s must start as scheduled, got %+v", run.Scheduled, run)
+		}
+	}
+}
+
+func TestOneOffBlackout(t *testing.T) {
+	from := metav1.NewTime(at("10:00"))
+	to := metav1.NewTime(at("14:00"))
+	windows := mustParseWindows(t, &types.BackupWindows{
+		Blackouts: []types.BlackoutPeriod{{Name: "release", From: &from, To: &to}},
+	})
+	if !windows.Allowed(at("09:59")) || windows.Allowed(at("10:00")) || !windows.Allowed(at("14:00")) {
+		t.Errorf("one-off blackout must block [10:00, 14:00)")
+	}
+}
+
+func TestEffectiveRuns(t *testing.T) {
+	tests := map[string]struct {
+		schedule  string
+		windows   types.BackupWindows
+		effective []time.Time
+	}{
+		"defer to window": {
+			schedule:  "0 12 * * *",
+			windows:   types.BackupWindows{Allowed: []types.TimeWindow{{Start: "22:00", End: "04:00"}}},
+			effective: []time.Time{at("22:00"), at("22:00").AddDate(0, 0, 1)},
+		},
+		"skip outside window": {
+			schedule: "0 12 * * *",
+			windows: types.BackupWindows{
+				Allowed: []types.TimeWindow{{Start: "22:00", End: "04:00"}},
+				Policy:  PolicySkip,
+			},
+			effective: []time.Time{{}, {}},
+		},
+		"skip when deferred past next run": {
+			schedule:  "0 * * * *",
+			windows:   types.BackupWindows{Blackouts: []types.BlackoutPeriod{{Schedule: "0 0 * * *", Duration: "150m"}}},
+			effective: []time.Time{{}, {}, at("02:30"), at("03:00")},
+		},
+		"defer within blackout gap": {
+			schedule:  "0 */6 * * *",
+			windows:   types.BackupWindows{Blackouts: []types.BlackoutPeriod{{Schedule: "0 0 * * *", Duration: "150m"}}},
+			effective: []time.Time{at("02:30"), at("06:00")},
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			windows := mustParseWindows(t, &test.windows)
+			runs := EffectiveRuns(mustParse(t, test.schedule), windows, at("00:00").Add(-time.Second), len(test.effective))
+			if len(runs) != len(test.effective) {
+				t.Fatalf("got %d runs, expected %d", len(runs), len(test.effective))
+			}
+			for i, run := range runs {
+				if run.Skipped != test.effective[i].IsZero() || !run.Effective.Equal(test.effective[i]) {
+					t.Errorf("run %d: got %+v, expected effective %s", i, run, test.effective[i])
+				}
+			}
+		})
+	}
+}
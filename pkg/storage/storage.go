@@ -31,7 +31,37 @@ var (
 	logger = util.GetLogger()
 )
 
+const (
+	TypeProvisioned = "provisioned"
+	TypePv          = "pv"
+	TypeEphemeral   = "ephemeral"
+	TypeS3          = "s3"
+	TypeGcs         = "gcs"
+	TypeAzure       = "azure"
+
+	hostnameLabel = "kubernetes.io/hostname"
+)
+
+var (
+	Types = []string{TypeProvisioned, TypePv, TypeEphemeral, TypeS3, TypeGcs, TypeAzure}
+)
+
+// IsPersistent reports whether storage type keeps backups on a persistent volume claim,
+// other types use an ephemeral volume as a local buffer.
+func IsPersistent(storageType string) bool {
+	switch storageType {
+	case TypeEphemeral, TypeS3, TypeGcs, TypeAzure:
+		return false
+	}
+	return true
+}
+
 func NewPvc(pvcName string, storageEntity *types.Storage, idx int) *corev1.PersistentVolumeClaim {
+	return NewPvcInNamespace(pvcName, "", storageEntity, idx)
+}
+
+// NewPvcInNamespace builds the claim in namespace, empty namespace falls back to WATCH_NAMESPACE.
+func NewPvcInNamespace(pvcName string, namespace string, storageEntity *types.Storage, idx int) *corev1.PersistentVolumeClaim {
 	var pvcSpec corev1.PersistentVolumeClaimSpec
 	switch storageEntity.Type {
 	case "provisioned":
@@ -71,16 +101,71 @@ func NewPvc(pvcName string, storageEntity *types.Storage, idx int) *corev1.Persi
 	pvc := &corev1.PersistentVolumeClaim{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      pvcName,
-			Namespace: util.GetNameSpace(),
+			Namespace: util.NamespaceOrDefault(namespace),
 		},
 		Spec: pvcSpec,
 	}
 	return pvc
 }
 
+// PinToNodes restricts affinity to the nodes listed in storageEntity. Nodes are ordered as
+// Volumes and Selectors, so the pod is pinned to the node of the idx-th claim. With
+// NodeFailover the pod may run on any listed node and the node of the claim is preferred.
+func PinToNodes(affinity *corev1.Affinity, storageEntity *types.Storage, idx int) {
+	nodes := storageEntity.Nodes
+	if len(nodes) == 0 {
+		return
+	}
+	node := nodes[0]
+	if idx > 0 && idx <= len(nodes) {
+		node = nodes[idx-1]
+	}
+	required := nodes
+	if !storageEntity.NodeFailover {
+		// hostPath and local volumes are only reachable from their own node
+		required = []string{node}
+	}
+	if affinity.NodeAffinity == nil {
+		affinity.NodeAffinity = &corev1.NodeAffinity{}
+	}
+	nodeAffinity := affinity.NodeAffinity
+	if nodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution == nil {
+		nodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution = &corev1.NodeSelector{}
+	}
+	selector := nodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution
+	if len(selector.NodeSelectorTerms) == 0 {
+		selector.NodeSelectorTerms = []corev1.NodeSelectorTerm{{}}
+	}
+	// terms are ORed, so the requirement is added to each of them
+	for i := range selector.NodeSelectorTerms {
+		term := &selector.NodeSelectorTerms[i]
+		term.MatchExpressions = append(term.MatchExpressions, corev1.NodeSelectorRequirement{
+			Key:      hostnameLabel,
+			Operator: corev1.NodeSelectorOpIn,
+			Values:   required,
+		})
+	}
+	if len(required) > 1 {
+		nodeAffinity.PreferredDuringSchedulingIgnoredDuringExecution = append(nodeAffinity.PreferredDuringSchedulingIgnoredDuringExecution,
+			corev1.PreferredSchedulingTerm{
+				Weight: 100,
+				Preference: corev1.NodeSelectorTerm{
+					MatchExpressions: []corev1.NodeSelectorRequirement{
+						{Key: hostnameLabel, Operator: corev1.NodeSelectorOpIn, Values: []string{node}},
+					},
+				},
+			})
+	}
+}
+
 func GetConfigMapByName(configMapName string, configMapKey string) *corev1.ConfigMap {
-	namespace := util.GetNameSpace()
-	filePath := fmt.Sprintf("/opt/operator/%s", configMapName)
+	return GetConfigMapFromFile(configMapName, "", configMapName, configMapKey)
+}
+
+// GetConfigMapFromFile builds config map configMapName in namespace with the content of
+// operator file fileName, which may differ for instance scoped config maps.
+func GetConfigMapFromFile(configMapName string, namespace string, fileName string, configMapKey string) *corev1.ConfigMap {
+	filePath := fmt.Sprintf("/opt/operator/%s", fileName)
 	bytes, e := ioutil.ReadFile(filePath)
 	if e != nil {
 		logger.Error("Failed to read from file", zap.Error(e))
@@ -88,7 +173,7 @@ func GetConfigMapByName(configMapName string, configMapKey string) *corev1.Confi
 	return &corev1.ConfigMap{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      configMapName,
-			Namespace: namespace,
+			Namespace: util.NamespaceOrDefault(namespace),
 		},
 		Data: map[string]string{
 			configMapKey: string(bytes),
@@ -115,4 +200,4 @@ func getAccessModes(accessModes []string) []corev1.PersistentVolumeAccessMode {
 		}
 	}
 	return result
-}
\ No newline at end of file
+}
@@ -0,0 +1,106 @@
+// Copyright 2024-2025 NetCracker Technology Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/Netcracker/pgskipper-operator-core/api/v1"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func requiredNodes(affinity *corev1.Affinity) [][]string {
+	var res [][]string
+	for _, term := range affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms {
+		for _, expr := range term.MatchExpressions {
+			if expr.Key == hostnameLabel {
+				res = append(res, expr.Values)
+			}
+		}
+	}
+	return res
+}
+
+func TestPinToNodes(t *testing.T) {
+	tests := []struct {
+		name      string
+		storage   types.Storage
+		idx       int
+		required  []string
+		preferred []string
+	}{
+		{
+			name:     "hostPath volumes stay on the node of the claim",
+			storage:  types.Storage{Type: TypePv, Volumes: []string{"pv-1", "pv-2"}, Nodes: []string{"node-1", "node-2"}},
+			idx:      2,
+			required: []string{"node-2"},
+		},
+		{
+			name:     "claim without node",
+			storage:  types.Storage{Type: TypePv, Volumes: []string{"pv-1", "pv-2"}, Nodes: []string{"node-1"}},
+			idx:      2,
+			required: []string{"node-1"},
+		},
+		{
+			name:      "failover",
+			storage:   types.Storage{Type: TypePv, Selectors: []string{"pv=1", "pv=2"}, Nodes: []string{"node-1", "node-2"}, NodeFailover: true},
+			idx:       1,
+			required:  []string{"node-1", "node-2"},
+			preferred: []string{"node-1"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			affinity := &corev1.Affinity{}
+			PinToNodes(affinity, &tt.storage, tt.idx)
+			if got := requiredNodes(affinity); !reflect.DeepEqual(got, [][]string{tt.required}) {
+				t.Errorf("required nodes = %v, want %v", got, tt.required)
+			}
+			preferred := affinity.NodeAffinity.PreferredDuringSchedulingIgnoredDuringExecution
+			if tt.preferred == nil {
+				if len(preferred) != 0 {
+					t.Errorf("preferred = %v, want none", preferred)
+				}
+				return
+			}
+			if len(preferred) != 1 || !reflect.DeepEqual(preferred[0].Preference.MatchExpressions[0].Values, tt.preferred) {
+				t.Errorf("preferred = %v, want %v", preferred, tt.preferred)
+			}
+		})
+	}
+}
+
+func TestPinToNodesKeepsTerms(t *testing.T) {
+	affinity := &corev1.Affinity{NodeAffinity: &corev1.NodeAffinity{
+		RequiredDuringSchedulingIgnoredDuringExecution: &corev1.NodeSelector{NodeSelectorTerms: []corev1.NodeSelectorTerm{
+			{MatchExpressions: []corev1.NodeSelectorRequirement{{Key: "zone", Operator: corev1.NodeSelectorOpIn, Values: []string{"a"}}}},
+			{MatchExpressions: []corev1.NodeSelectorRequirement{{Key: "zone", Operator: corev1.NodeSelectorOpIn, Values: []string{"b"}}}},
+		}},
+	}}
+	PinToNodes(affinity, &types.Storage{Nodes: []string{"node-1"}}, 1)
+	want := [][]string{{"node-1"}, {"node-1"}}
+	if got := requiredNodes(affinity); !reflect.DeepEqual(got, want) {
+		t.Errorf("required nodes = %v, want %v", got, want)
+	}
+}
+
+func TestPinToNodesWithoutNodes(t *testing.T) {
+	affinity := &corev1.Affinity{}
+	PinToNodes(affinity, &types.Storage{Type: TypeProvisioned}, 1)
+	if affinity.NodeAffinity != nil {
+		t.Errorf("NodeAffinity = %v, want nil", affinity.NodeAffinity)
+	}
+}
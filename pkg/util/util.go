@@ -17,10 +17,14 @@ package util
 import (
 	"crypto/rand"
 	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
 	"os"
+	"strconv"
+	"strings"
+	"time"
 
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
@@ -30,6 +34,14 @@ func GetNameSpace() string {
 	return os.Getenv("WATCH_NAMESPACE")
 }
 
+// NamespaceOrDefault returns namespace, or the watched namespace when it is empty.
+func NamespaceOrDefault(namespace string) string {
+	if namespace == "" {
+		return GetNameSpace()
+	}
+	return namespace
+}
+
 func GetEnv(key string, def string) string {
 	v := os.Getenv(key)
 	if len(v) == 0 {
@@ -38,6 +50,68 @@ func GetEnv(key string, def string) string {
 	return v
 }
 
+const (
+	maxNameLength   = 63
+	nameHashLength  = 8
+	nameReplacement = '-'
+)
+
+// InstanceName returns the name of an object that belongs to the given instance.
+// Empty instance keeps the base name, so single cluster installations are not renamed.
+func InstanceName(base string, instance string) string {
+	if instance == "" {
+		return base
+	}
+	return DNSName(fmt.Sprintf("%s-%s", base, instance))
+}
+
+// DNSName converts name to a valid DNS-1123 label. Names longer than 63 characters
+// are truncated and suffixed with a hash of the full name to keep them unique.
+func DNSName(name string) string {
+	sanitized := strings.Trim(strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= '0' && r <= '9', r == '-':
+			return r
+		case r >= 'A' && r <= 'Z':
+			return r + ('a' - 'A')
+		default:
+			return nameReplacement
+		}
+	}, name), "-")
+	if len(sanitized) <= maxNameLength {
+		return sanitized
+	}
+	hash := fmt.Sprintf("%x", sha256.Sum256([]byte(name)))[:nameHashLength]
+	prefix := strings.TrimRight(sanitized[:maxNameLength-nameHashLength-1], "-")
+	return fmt.Sprintf("%s-%s", prefix, hash)
+}
+
+var ageUnits = map[byte]time.Duration{
+	's': time.Second,
+	'm': time.Minute,
+	'h': time.Hour,
+	'd': 24 * time.Hour,
+	'w': 7 * 24 * time.Hour,
+	'y': 365 * 24 * time.Hour,
+}
+
+// ParseAge parses age in the backup daemon format, a number followed by
+// one of s, m, h, d, w or y unit, e.g. "7d".
+func ParseAge(age string) (time.Duration, error) {
+	if len(age) < 2 {
+		return 0, fmt.Errorf("invalid age %q", age)
+	}
+	unit, ok := ageUnits[age[len(age)-1]]
+	if !ok {
+		return 0, fmt.Errorf("invalid age %q, unit must be one of s, m, h, d, w, y", age)
+	}
+	value, err := strconv.Atoi(age[:len(age)-1])
+	if err != nil || value < 0 {
+		return 0, fmt.Errorf("invalid age %q", age)
+	}
+	return time.Duration(value) * unit, nil
+}
+
 func GetLogger() *zap.Logger {
 	atom := zap.NewAtomicLevel()
 	encoderCfg := zap.NewProductionEncoderConfig()
@@ -63,6 +137,13 @@ func randString(strSize int) string {
 	return string(bytes)
 }
 
+// RandomToken returns hex encoded random token of size bytes.
+func RandomToken(size int) string {
+	bytes := make([]byte, size)
+	rand.Read(bytes)
+	return hex.EncodeToString(bytes)
+}
+
 func HashJson(o interface{}) string {
     cr, _ := json.Marshal(o)
 	hash := sha256.New()
@@ -0,0 +1,82 @@
+// Copyright 2024-2025 NetCracker Technology Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestDNSName(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{name: "valid", in: "postgres-backup-daemon", want: "postgres-backup-daemon"},
+		{name: "upper case", in: "Backup-Daemon-EU", want: "backup-daemon-eu"},
+		{name: "invalid characters", in: "backup_daemon.eu/1", want: "backup-daemon-eu-1"},
+		{name: "trimmed", in: "_backup-daemon_", want: "backup-daemon"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := DNSName(tt.in); got != tt.want {
+				t.Errorf("DNSName(%q) = %q, want %q", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDNSNameTruncated(t *testing.T) {
+	long := strings.Repeat("a", 70)
+	first, second := DNSName(long+"-first"), DNSName(long+"-second")
+	for _, name := range []string{first, second} {
+		if len(name) > maxNameLength {
+			t.Errorf("DNSName() = %q, longer than %d characters", name, maxNameLength)
+		}
+		if !strings.HasPrefix(name, strings.Repeat("a", 10)) {
+			t.Errorf("DNSName() = %q, prefix is not kept", name)
+		}
+	}
+	if first == second {
+		t.Errorf("DNSName() = %q for different names", first)
+	}
+	if DNSName(long+"-first") != first {
+		t.Errorf("DNSName() is not stable")
+	}
+}
+
+func TestInstanceName(t *testing.T) {
+	tests := []struct {
+		name     string
+		base     string
+		instance string
+		want     string
+	}{
+		{name: "default instance", base: "postgres-backup-daemon", want: "postgres-backup-daemon"},
+		{name: "instance", base: "postgres-backup-daemon", instance: "eu", want: "postgres-backup-daemon-eu"},
+		{name: "sanitized instance", base: "postgres-backup-daemon", instance: "EU_West", want: "postgres-backup-daemon-eu-west"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := InstanceName(tt.base, tt.instance); got != tt.want {
+				t.Errorf("InstanceName(%q, %q) = %q, want %q", tt.base, tt.instance, got, tt.want)
+			}
+		})
+	}
+	if got := InstanceName("postgres-backup-daemon", strings.Repeat("x", 60)); len(got) > maxNameLength {
+		t.Errorf("InstanceName() = %q, longer than %d characters", got, maxNameLength)
+	}
+}
@@ -0,0 +1,662 @@
+// Copyright 2024-2025 NetCracker Technology Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validation
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	types "github.com/Netcracker/pgskipper-operator-core/api/v1"
+	"github.com/Netcracker/pgskipper-operator-core/pkg/eviction"
+	"github.com/Netcracker/pgskipper-operator-core/pkg/schedule"
+	"github.com/Netcracker/pgskipper-operator-core/pkg/storage"
+	"github.com/Netcracker/pgskipper-operator-core/pkg/util"
+	"k8s.io/apimachinery/pkg/api/resource"
+	k8svalidation "k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+const (
+	maxEndpointsWorkers    = 64
+	maxPort                = 65535
+	maxGranularConcurrency = 64
+	// pg_basebackup --max-rate limits
+	minReadRate = 32 * 1024
+	maxReadRate = 1024 * 1024 * 1024
+)
+
+var (
+	// maxCompressionLevels by algorithm, level 0 selects the algorithm default
+	maxCompressionLevels = map[string]int{
+		"gzip": 9,
+		"zstd": 19,
+		"lz4":  12,
+		"none": 0,
+	}
+)
+
+// ValidateBackupDaemon checks backup daemon settings, zero values are treated as defaults.
+func ValidateBackupDaemon(backupDaemon *types.BackupDaemon, path *field.Path) field.ErrorList {
+	var errs field.ErrorList
+	errs = append(errs, validateWorkers(backupDaemon.PublicEndpointsWorkers, path.Child("publicEndpointsWorkers"))...)
+	errs = append(errs, validateWorkers(backupDaemon.PrivateEndpointsWorkers, path.Child("privateEndpointsWorkers"))...)
+	errs = append(errs, validateWorkers(backupDaemon.ArchiveEndpointsWorkers, path.Child("archiveEndpointsWorkers"))...)
+	errs = append(errs, validatePort(backupDaemon.PgPort, path.Child("pgPort"))...)
+	errs = append(errs, validateBackupDaemonAuth(backupDaemon.Auth, path.Child("auth"))...)
+	errs = append(errs, validateBackupDaemonTls(backupDaemon.Tls, path.Child("tls"))...)
+	errs = append(errs, validatePgSsl(backupDaemon.SslMode, backupDaemon.Ssl, path)...)
+	errs = append(errs, validateS3Storage(backupDaemon.S3Storage, path.Child("s3Storage"))...)
+	if backupDaemon.S3Storage != nil {
+		errs = append(errs, validateObjectLock(backupDaemon.S3Storage.ObjectLock, backupDaemon.EvictionPolicy,
+			path.Child("s3Storage", "objectLock"), path.Child("evictionPolicy"))...)
+	}
+	errs = append(errs, validateBackupStorage(backupDaemon, path)...)
+	errs = append(errs, validateCopyTargets(backupDaemon.CopyTargets, path.Child("copyTargets"))...)
+	errs = append(errs, validateEviction(backupDaemon, path)...)
+	errs = append(errs, validateSchedules(backupDaemon, path)...)
+	errs = append(errs, validateGranularSchedules(backupDaemon, path.Child("granularSchedules"))...)
+	errs = append(errs, validateGranularDumpOptions(backupDaemon, path.Child("granularDumpOptions"))...)
+	errs = append(errs, validateCompression(backupDaemon, path)...)
+	if _, err := schedule.ParseWindows(backupDaemon.BackupWindows, backupDaemon.Timezone); err != nil {
+		errs = append(errs, field.Invalid(path.Child("backupWindows"), backupDaemon.BackupWindows, err.Error()))
+	}
+	if hooks := backupDaemon.Hooks; hooks != nil {
+		errs = append(errs, validateHooks(hooks.PreBackup, path.Child("hooks", "preBackup"))...)
+		errs = append(errs, validateHooks(hooks.PostBackup, path.Child("hooks", "postBackup"))...)
+	}
+	if notifications := backupDaemon.Notifications; notifications != nil {
+		errs = append(errs, validateNotificationTargets(notifications.Targets, path.Child("notifications", "targets"))...)
+	}
+	errs = append(errs, validateThrottling(backupDaemon.Throttling, path.Child("throttling"))...)
+	errs = append(errs, validateSizeRetention(backupDaemon, path.Child("sizeRetention"))...)
+	return errs
+}
+
+func validateSizeRetention(backupDaemon *types.BackupDaemon, path *field.Path) field.ErrorList {
+	retention := backupDaemon.SizeRetention
+	if retention == nil {
+		return nil
+	}
+	var errs field.ErrorList
+	if retention.Quota == "" && retention.MaxPercent == 0 {
+		errs = append(errs, field.Required(path, "quota or maxPercent is required"))
+	} else if _, err := eviction.ParseQuota(retention.Quota, retention.MaxPercent, backupDaemon.Storage.Size); err != nil {
+		errs = append(errs, field.Invalid(path, retention, err.Error()))
+	}
+	if backupDaemon.Storage.Type == storage.TypeS3 && backupDaemon.S3Storage != nil && backupDaemon.S3Storage.ObjectLock != nil {
+		errs = append(errs, field.Forbidden(path, "size retention may evict backups locked by S3 object lock"))
+	}
+	if retention.MinFullBackups < 0 {
+		errs = append(errs, field.Invalid(path.Child("minFullBackups"), retention.MinFullBackups, "must not be negative"))
+	}
+	return errs
+}
+
+func validateThrottling(throttling *types.BackupThrottling, path *field.Path) field.ErrorList {
+	if throttling == nil {
+		return nil
+	}
+	var errs field.ErrorList
+	if throttling.UploadBandwidth != "" {
+		if rate, err := resource.ParseQuantity(throttling.UploadBandwidth); err != nil || rate.Sign() <= 0 {
+			errs = append(errs, field.Invalid(path.Child("uploadBandwidth"), throttling.UploadBandwidth, "must be a positive quantity"))
+		}
+	}
+	if throttling.ReadRate != "" {
+		rate, err := resource.ParseQuantity(throttling.ReadRate)
+		if err != nil || rate.Value() < minReadRate || rate.Value() > maxReadRate {
+			errs = append(errs, field.Invalid(path.Child("readRate"), throttling.ReadRate, "must be between 32Ki and 1Gi"))
+		}
+	}
+	if concurrency := throttling.GranularConcurrency; concurrency < 0 || concurrency > maxGranularConcurrency {
+		errs = append(errs, field.Invalid(path.Child("granularConcurrency"), concurrency,
+			fmt.Sprintf("must be between 0 and %d", maxGranularConcurrency)))
+	}
+	if priority := throttling.IoPriority; priority != nil {
+		switch priority.Class {
+		case "", "best-effort", "idle":
+		default:
+			errs = append(errs, field.NotSupported(path.Child("ioPriority", "class"), priority.Class, []string{"best-effort", "idle"}))
+		}
+		if level := priority.Level; level != nil {
+			if *level < 0 || *level > 7 {
+				errs = append(errs, field.Invalid(path.Child("ioPriority", "level"), *level, "must be between 0 and 7"))
+			} else if priority.Class == "idle" {
+				errs = append(errs, field.Invalid(path.Child("ioPriority", "level"), *level, "idle class has no levels"))
+			}
+		}
+	}
+	return errs
+}
+
+func validateNotificationTargets(targets []types.NotificationTarget, path *field.Path) field.ErrorList {
+	var errs field.ErrorList
+	names := map[string]bool{}
+	for i, target := range targets {
+		targetPath := path.Index(i)
+		errs = append(errs, validateName(target.Name, names, targetPath.Child("name"))...)
+		switch target.Type {
+		case "http", "slack", "teams":
+		default:
+			errs = append(errs, field.NotSupported(targetPath.Child("type"), target.Type, []string{"http", "slack", "teams"}))
+		}
+		if target.Url == nil || target.Url.Name == "" || target.Url.Key == "" {
+			errs = append(errs, field.Required(targetPath.Child("url"), "secret name and key are required"))
+		}
+		for j, event := range target.Events {
+			switch event {
+			case "success", "failure", "eviction":
+			default:
+				errs = append(errs, field.NotSupported(targetPath.Child("events").Index(j), event, []string{"success", "failure", "eviction"}))
+			}
+		}
+	}
+	return errs
+}
+
+// validateName checks that name is set, unique within names and a DNS-1123 label, as it
+// is used in mounted file and directory paths. A label can't contain "/" or "..".
+func validateName(name string, names map[string]bool, path *field.Path) field.ErrorList {
+	if name == "" {
+		return field.ErrorList{field.Required(path, "")}
+	}
+	var errs field.ErrorList
+	if names[name] {
+		errs = append(errs, field.Duplicate(path, name))
+	}
+	names[name] = true
+	for _, msg := range k8svalidation.IsDNS1123Label(name) {
+		errs = append(errs, field.Invalid(path, name, msg))
+	}
+	return errs
+}
+
+// validateHooks checks that every hook has exactly one action.
+func validateHooks(hooks []types.BackupHook, path *field.Path) field.ErrorList {
+	var errs field.ErrorList
+	names := map[string]bool{}
+	for i, hook := range hooks {
+		hookPath := path.Index(i)
+		errs = append(errs, validateName(hook.Name, names, hookPath.Child("name"))...)
+		actions := 0
+		if len(hook.Sql) > 0 {
+			actions++
+		}
+		if len(hook.Exec) > 0 {
+			actions++
+		}
+		if hook.Http != nil {
+			actions++
+			errs = append(errs, validateHttpUrl(hook.Http.Url, hookPath.Child("http", "url"))...)
+			errs = append(errs, validateHttpHook(hook.Http, hookPath.Child("http"))...)
+		}
+		if actions != 1 {
+			errs = append(errs, field.Invalid(hookPath, hook.Name, "exactly one of sql, exec and http must be set"))
+		}
+		if hook.Timeout != "" {
+			if _, err := util.ParseAge(hook.Timeout); err != nil {
+				errs = append(errs, field.Invalid(hookPath.Child("timeout"), hook.Timeout, err.Error()))
+			}
+		}
+		switch hook.FailurePolicy {
+		case "", "abort", "continue":
+		default:
+			errs = append(errs, field.NotSupported(hookPath.Child("failurePolicy"), hook.FailurePolicy, []string{"abort", "continue"}))
+		}
+	}
+	return errs
+}
+
+func validateHttpHook(hook *types.HttpHook, path *field.Path) field.ErrorList {
+	var errs field.ErrorList
+	switch hook.Method {
+	case "", "GET", "POST", "PUT", "PATCH", "DELETE":
+	default:
+		errs = append(errs, field.NotSupported(path.Child("method"), hook.Method, []string{"GET", "POST", "PUT", "PATCH", "DELETE"}))
+	}
+	for i, header := range hook.Headers {
+		headerPath := path.Child("headers").Index(i)
+		if header.Name == "" {
+			errs = append(errs, field.Required(headerPath.Child("name"), ""))
+		}
+		switch {
+		case header.Value != "" && header.ValueFrom != nil:
+			errs = append(errs, field.Forbidden(headerPath, "only one of value and valueFrom may be set"))
+		case header.ValueFrom != nil && (header.ValueFrom.Name == "" || header.ValueFrom.Key == ""):
+			errs = append(errs, field.Required(headerPath.Child("valueFrom"), "secret name and key are required"))
+		}
+	}
+	return errs
+}
+
+func validateHttpUrl(rawUrl string, path *field.Path) field.ErrorList {
+	parsed, err := url.Parse(rawUrl)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return field.ErrorList{field.Invalid(path, rawUrl, "must be an absolute http or https URL")}
+	}
+	return nil
+}
+
+func validateCompression(backupDaemon *types.BackupDaemon, path *field.Path) field.ErrorList {
+	algorithm := backupDaemon.CompressionAlgorithm
+	if algorithm == "" {
+		algorithm = "gzip"
+	}
+	maxLevel, ok := maxCompressionLevels[algorithm]
+	if !ok {
+		return field.ErrorList{field.NotSupported(path.Child("compressionAlgorithm"), algorithm, []string{"gzip", "zstd", "lz4", "none"})}
+	}
+	if level := backupDaemon.CompressionLevel; level < 0 || level > maxLevel {
+		return field.ErrorList{field.Invalid(path.Child("compressionLevel"), level,
+			fmt.Sprintf("must be between 0 and %d for %s", maxLevel, algorithm))}
+	}
+	return nil
+}
+
+func validateGranularDumpOptions(backupDaemon *types.BackupDaemon, path *field.Path) field.ErrorList {
+	options := backupDaemon.GranularDumpOptions
+	if options == nil {
+		return nil
+	}
+	errs := validateDumpOptions(options, path)
+	if len(options.ExcludedExtensions) > 0 && backupDaemon.ExcludedExtensions != "" {
+		errs = append(errs, field.Forbidden(path.Child("excludedExtensions"), "excludedExtensions is already set"))
+	}
+	return errs
+}
+
+func validateDumpOptions(options *types.GranularDumpOptions, path *field.Path) field.ErrorList {
+	var errs field.ErrorList
+	switch options.Format {
+	case "", "custom", "directory", "tar", "plain":
+	default:
+		errs = append(errs, field.NotSupported(path.Child("format"), options.Format, []string{"custom", "directory", "tar", "plain"}))
+	}
+	if options.Jobs < 0 {
+		errs = append(errs, field.Invalid(path.Child("jobs"), options.Jobs, "must not be negative"))
+	}
+	if options.Jobs > 1 && options.Format != "directory" {
+		errs = append(errs, field.Invalid(path.Child("jobs"), options.Jobs, "parallel jobs require directory format"))
+	}
+	for _, names := range []struct {
+		values []string
+		path   *field.Path
+	}{
+		{options.ExcludedExtensions, path.Child("excludedExtensions")},
+		{options.Schemas, path.Child("schemas")},
+		{options.ExcludedSchemas, path.Child("excludedSchemas")},
+		{options.ExcludedTables, path.Child("excludedTables")},
+	} {
+		for i, name := range names.values {
+			if strings.TrimSpace(name) == "" {
+				errs = append(errs, field.Required(names.path.Index(i), "name must not be empty"))
+			}
+		}
+	}
+	return errs
+}
+
+func validateGranularSchedules(backupDaemon *types.BackupDaemon, path *field.Path) field.ErrorList {
+	var errs field.ErrorList
+	if len(backupDaemon.GranularSchedules) > 0 {
+		// legacy schedule explicitly disabled with None does not compete with structured ones
+		if legacy, err := schedule.Parse(backupDaemon.GranularBackupSchedule, ""); err != nil || !legacy.Disabled() {
+			errs = append(errs, field.Forbidden(path, "granularBackupSchedule is already set"))
+		}
+		if backupDaemon.DatabasesToSchedule != "" {
+			errs = append(errs, field.Forbidden(path, "databasesToSchedule is already set"))
+		}
+	}
+	timeout := time.Duration(backupDaemon.BackupTimeout) * time.Second
+	databases := map[string]bool{}
+	for i, granular := range backupDaemon.GranularSchedules {
+		schedulePath := path.Index(i)
+		switch {
+		case granular.Database != "" && granular.DatabasePattern != "":
+			errs = append(errs, field.Forbidden(schedulePath, "only one of database and databasePattern may be set"))
+		case granular.Database == "" && granular.DatabasePattern == "":
+			errs = append(errs, field.Required(schedulePath, "either database or databasePattern must be set"))
+		case granular.Database != "":
+			if databases[granular.Database] {
+				errs = append(errs, field.Duplicate(schedulePath.Child("database"), granular.Database))
+			}
+			databases[granular.Database] = true
+		default:
+			errs = append(errs, validatePattern(granular.DatabasePattern, schedulePath.Child("databasePattern"))...)
+		}
+		for j, exclude := range granular.Exclude {
+			errs = append(errs, validatePattern(exclude, schedulePath.Child("exclude").Index(j))...)
+		}
+		if granular.Schedule == "" {
+			errs = append(errs, field.Required(schedulePath.Child("schedule"), ""))
+		} else if parsed, err := schedule.Parse(granular.Schedule, backupDaemon.Timezone); err != nil {
+			errs = append(errs, field.Invalid(schedulePath.Child("schedule"), granular.Schedule, err.Error()))
+		} else if err := parsed.CheckTimeout(time.Now(), timeout); err != nil {
+			errs = append(errs, field.Invalid(schedulePath.Child("schedule"), granular.Schedule, err.Error()))
+		}
+		if granular.Retention != "" {
+			if _, err := eviction.ParseGranular(granular.Retention); err != nil {
+				errs = append(errs, field.Invalid(schedulePath.Child("retention"), granular.Retention, err.Error()))
+			}
+		}
+		if options := granular.DumpOptions; options != nil {
+			errs = append(errs, validateDumpOptions(options, schedulePath.Child("dumpOptions"))...)
+			if len(options.ExcludedExtensions) > 0 {
+				errs = append(errs, field.Forbidden(schedulePath.Child("dumpOptions", "excludedExtensions"),
+					"excluded extensions apply to all databases"))
+			}
+		}
+	}
+	return errs
+}
+
+func validatePattern(pattern string, path *field.Path) field.ErrorList {
+	if _, err := regexp.Compile(pattern); err != nil {
+		return field.ErrorList{field.Invalid(path, pattern, err.Error())}
+	}
+	return nil
+}
+
+// validateSchedules checks cron schedules and that a backup may finish before the next one starts.
+func validateSchedules(backupDaemon *types.BackupDaemon, path *field.Path) field.ErrorList {
+	var errs field.ErrorList
+	if _, err := time.LoadLocation(backupDaemon.Timezone); err != nil {
+		return append(errs, field.Invalid(path.Child("timezone"), backupDaemon.Timezone, err.Error()))
+	}
+	timeout := time.Duration(backupDaemon.BackupTimeout) * time.Second
+	for _, spec := range []struct {
+		value string
+		path  *field.Path
+	}{
+		{backupDaemon.BackupSchedule, path.Child("backupSchedule")},
+		{backupDaemon.GranularBackupSchedule, path.Child("granularBackupSchedule")},
+	} {
+		parsed, err := schedule.Parse(spec.value, backupDaemon.Timezone)
+		if err != nil {
+			errs = append(errs, field.Invalid(spec.path, spec.value, err.Error()))
+			continue
+		}
+		if err := parsed.CheckTimeout(time.Now(), timeout); err != nil {
+			errs = append(errs, field.Invalid(spec.path, spec.value, err.Error()))
+		}
+	}
+	return errs
+}
+
+// validateObjectLock checks lock settings and that eviction policy does not try to remove
+// backups which are still locked.
+func validateObjectLock(lock *types.S3ObjectLock, evictionPolicy string, path *field.Path, evictionPath *field.Path) field.ErrorList {
+	var errs field.ErrorList
+	if lock == nil {
+		return errs
+	}
+	switch lock.Mode {
+	case "governance", "compliance":
+	default:
+		errs = append(errs, field.NotSupported(path.Child("mode"), lock.Mode, []string{"governance", "compliance"}))
+	}
+	retention, err := util.ParseAge(lock.Retention)
+	if err != nil {
+		return append(errs, field.Invalid(path.Child("retention"), lock.Retention, err.Error()))
+	}
+	if evictionPolicy == "" {
+		return errs
+	}
+	policy, err := eviction.Parse(evictionPolicy)
+	if err != nil {
+		// reported by eviction policy validation
+		return errs
+	}
+	if policy.MinAge() < retention {
+		errs = append(errs, field.Invalid(evictionPath, evictionPolicy,
+			fmt.Sprintf("policy evicts backups younger than object lock retention %s", lock.Retention)))
+	}
+	return errs
+}
+
+func validateEviction(backupDaemon *types.BackupDaemon, path *field.Path) field.ErrorList {
+	var errs field.ErrorList
+	for _, policy := range []struct {
+		value string
+		path  *field.Path
+	}{
+		{backupDaemon.EvictionPolicy, path.Child("evictionPolicy")},
+		{backupDaemon.EvictionBinaryPolicy, path.Child("evictionBinaryPolicy")},
+	} {
+		if policy.value == "" {
+			continue
+		}
+		if _, err := eviction.Parse(policy.value); err != nil {
+			errs = append(errs, field.Invalid(policy.path, policy.value, err.Error()))
+		}
+	}
+	if value := backupDaemon.ArchiveEvictionPolicy; value != "" {
+		if err := eviction.ValidateArchive(value); err != nil {
+			errs = append(errs, field.Invalid(path.Child("archiveEvictionPolicy"), value, err.Error()))
+		}
+	}
+	if value := backupDaemon.GranularEviction; value != "" {
+		if _, err := eviction.ParseGranular(value); err != nil {
+			errs = append(errs, field.Invalid(path.Child("granularEviction"), value, err.Error()))
+		}
+	}
+	if value := backupDaemon.UseEvictionPolicyFirst; value != "" {
+		if _, err := strconv.ParseBool(value); err != nil {
+			errs = append(errs, field.Invalid(path.Child("useEvictionPolicyFirst"), value, "must be true or false"))
+		}
+	}
+	return errs
+}
+
+func validateCopyTargets(copyTargets []types.BackupCopyTarget, path *field.Path) field.ErrorList {
+	var errs field.ErrorList
+	names := map[string]bool{}
+	for i, target := range copyTargets {
+		targetPath := path.Index(i)
+		errs = append(errs, validateName(target.Name, names, targetPath.Child("name"))...)
+		if target.EvictionPolicy != "" {
+			if _, err := eviction.Parse(target.EvictionPolicy); err != nil {
+				errs = append(errs, field.Invalid(targetPath.Child("evictionPolicy"), target.EvictionPolicy, err.Error()))
+			}
+		}
+		switch target.Type {
+		case storage.TypeS3:
+			if target.S3Storage == nil {
+				errs = append(errs, field.Required(targetPath.Child("s3Storage"), "s3Storage is required for s3 target"))
+			} else {
+				errs = append(errs, validateS3Storage(target.S3Storage, targetPath.Child("s3Storage"))...)
+				if target.S3Storage.WebIdentity != nil {
+					errs = append(errs, field.Forbidden(targetPath.Child("s3Storage", "webIdentity"),
+						"web identity is supported by the primary storage only"))
+				}
+				errs = append(errs, validateObjectLock(target.S3Storage.ObjectLock, target.EvictionPolicy,
+					targetPath.Child("s3Storage", "objectLock"), targetPath.Child("evictionPolicy"))...)
+			}
+		case storage.TypeGcs:
+			if target.GcsStorage == nil || target.GcsStorage.Bucket == "" {
+				errs = append(errs, field.Required(targetPath.Child("gcsStorage", "bucket"), "bucket is required for gcs target"))
+			}
+		case storage.TypeAzure:
+			errs = append(errs, validateAzureStorage(target.AzureStorage, targetPath.Child("azureStorage"))...)
+		case storage.TypePv:
+			if target.ClaimName == "" {
+				errs = append(errs, field.Required(targetPath.Child("claimName"), "claimName is required for pv target"))
+			}
+		default:
+			errs = append(errs, field.NotSupported(targetPath.Child("type"), target.Type,
+				[]string{storage.TypeS3, storage.TypeGcs, storage.TypeAzure, storage.TypePv}))
+		}
+	}
+	return errs
+}
+
+// validateBackupStorage checks that the storage type is known and has its settings.
+func validateBackupStorage(backupDaemon *types.BackupDaemon, path *field.Path) field.ErrorList {
+	var errs field.ErrorList
+	storageType := backupDaemon.Storage.Type
+	typePath := path.Child("storage", "type")
+	switch storageType {
+	case "":
+		// empty type mounts the backup claim as it always did
+	case storage.TypeProvisioned, storage.TypePv:
+		if backupDaemon.Storage.Size == "" {
+			errs = append(errs, field.Required(path.Child("storage", "size"), fmt.Sprintf("size is required for %s storage", storageType)))
+		}
+	case storage.TypeEphemeral:
+	case storage.TypeS3:
+		if backupDaemon.S3Storage == nil {
+			errs = append(errs, field.Required(path.Child("s3Storage"), "s3Storage is required for s3 storage"))
+		}
+	case storage.TypeGcs:
+		gcsPath := path.Child("gcsStorage")
+		if backupDaemon.GcsStorage == nil {
+			errs = append(errs, field.Required(gcsPath, "gcsStorage is required for gcs storage"))
+		} else if backupDaemon.GcsStorage.Bucket == "" {
+			errs = append(errs, field.Required(gcsPath.Child("bucket"), ""))
+		}
+	case storage.TypeAzure:
+		errs = append(errs, validateAzureStorage(backupDaemon.AzureStorage, path.Child("azureStorage"))...)
+	default:
+		errs = append(errs, field.NotSupported(typePath, storageType, storage.Types))
+	}
+	return errs
+}
+
+func validateAzureStorage(azureStorage *types.AzureStorage, path *field.Path) field.ErrorList {
+	var errs field.ErrorList
+	if azureStorage == nil {
+		return append(errs, field.Required(path, "azureStorage is required for azure storage"))
+	}
+	if azureStorage.AccountName == "" {
+		errs = append(errs, field.Required(path.Child("accountName"), ""))
+	}
+	if azureStorage.Container == "" {
+		errs = append(errs, field.Required(path.Child("container"), ""))
+	}
+	if (azureStorage.AccountKey == nil) == (azureStorage.SasToken == nil) {
+		errs = append(errs, field.Invalid(path, azureStorage.AccountName, "exactly one of accountKey and sasToken must be set"))
+	}
+	return errs
+}
+
+// BackupDaemonWarnings returns settings which are valid but not recommended,
+// suitable for admission warnings.
+func BackupDaemonWarnings(backupDaemon *types.BackupDaemon, path *field.Path) []string {
+	var warnings []string
+	if backupDaemon.S3Storage != nil && backupDaemon.S3Storage.UntrustedCert {
+		warnings = append(warnings, fmt.Sprintf("%s: S3 certificate verification is disabled, use %s instead",
+			path.Child("s3Storage", "untrustedCert"), path.Child("s3Storage", "caBundle")))
+	}
+	return warnings
+}
+
+func validateS3Storage(s3Storage *types.S3Storage, path *field.Path) field.ErrorList {
+	var errs field.ErrorList
+	if s3Storage == nil {
+		return errs
+	}
+	if caBundle := s3Storage.CaBundle; caBundle != nil && caBundle.ConfigMap != nil && caBundle.Secret != nil {
+		errs = append(errs, field.Forbidden(path.Child("caBundle"), "only one of configMap and secret may be set"))
+	}
+	if webIdentity := s3Storage.WebIdentity; webIdentity != nil {
+		if webIdentity.RoleArn == "" {
+			errs = append(errs, field.Required(path.Child("webIdentity", "roleArn"), "role is required for web identity"))
+		}
+		if s3Storage.AccessKeyId != "" || s3Storage.SecretAccessKey != "" {
+			errs = append(errs, field.Forbidden(path.Child("webIdentity"), "static access keys can't be used with web identity"))
+		}
+	}
+	switch s3Storage.AddressingStyle {
+	case "", "auto", "path", "virtual":
+	default:
+		errs = append(errs, field.NotSupported(path.Child("addressingStyle"), s3Storage.AddressingStyle, []string{"auto", "path", "virtual"}))
+	}
+	if sse := s3Storage.ServerSideEncryption; sse != nil {
+		switch sse.Type {
+		case "SSE-S3":
+			if sse.KmsKeyId != "" {
+				errs = append(errs, field.Forbidden(path.Child("serverSideEncryption", "kmsKeyId"), "KMS key can be set only for SSE-KMS"))
+			}
+		case "SSE-KMS":
+		default:
+			errs = append(errs, field.NotSupported(path.Child("serverSideEncryption", "type"), sse.Type, []string{"SSE-S3", "SSE-KMS"}))
+		}
+	}
+	return errs
+}
+
+func validateBackupDaemonTls(tls *types.BackupDaemonTls, path *field.Path) field.ErrorList {
+	if tls == nil || !tls.Enabled {
+		return nil
+	}
+	if tls.SecretName == "" && (tls.Issuer == nil || tls.Issuer.Name == "") {
+		return field.ErrorList{field.Required(path, "either secretName or issuer.name must be set")}
+	}
+	return nil
+}
+
+func validateBackupDaemonAuth(auth *types.BackupDaemonAuth, path *field.Path) field.ErrorList {
+	if auth == nil || !auth.Enabled {
+		return nil
+	}
+	switch auth.Type {
+	case "", "basic", "token":
+		return nil
+	default:
+		return field.ErrorList{field.NotSupported(path.Child("type"), auth.Type, []string{"basic", "token"})}
+	}
+}
+
+// ValidateMetricCollector checks metric collector settings, zero values are treated as defaults.
+func ValidateMetricCollector(metricCollector *types.MetricCollector, path *field.Path) field.ErrorList {
+	var errs field.ErrorList
+	errs = append(errs, validatePort(metricCollector.PgPort, path.Child("pgPort"))...)
+	errs = append(errs, validatePgSsl(metricCollector.SslMode, metricCollector.Ssl, path)...)
+	return errs
+}
+
+// validatePgSsl checks that verifying SSL modes have a CA and client cert comes with its key.
+func validatePgSsl(sslMode string, ssl *types.PgSsl, path *field.Path) field.ErrorList {
+	var errs field.ErrorList
+	sslPath := path.Child("ssl")
+	if (sslMode == "verify-ca" || sslMode == "verify-full") && (ssl == nil || ssl.Ca == nil) {
+		errs = append(errs, field.Required(sslPath.Child("ca"), fmt.Sprintf("CA is required for sslMode %s", sslMode)))
+	}
+	if ssl != nil && ssl.Cert != nil && ssl.Key == nil {
+		errs = append(errs, field.Required(sslPath.Child("key"), "key is required for client certificate"))
+	}
+	if ssl != nil && ssl.Key != nil && ssl.Cert == nil {
+		errs = append(errs, field.Required(sslPath.Child("cert"), "cert is required for client key"))
+	}
+	return errs
+}
+
+func validateWorkers(workers int, path *field.Path) field.ErrorList {
+	if workers < 0 || workers > maxEndpointsWorkers {
+		return field.ErrorList{field.Invalid(path, workers, "must be between 1 and 64, or 0 for default")}
+	}
+	return nil
+}
+
+func validatePort(port int, path *field.Path) field.ErrorList {
+	if port < 0 || port > maxPort {
+		return field.ErrorList{field.Invalid(path, port, "must be between 1 and 65535, or 0 for default")}
+	}
+	return nil
+}
@@ -0,0 +1,265 @@
+// Copyright 2024-2025 NetCracker Technology Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validation
+
+import (
+	"reflect"
+	"testing"
+
+	types "github.com/Netcracker/pgskipper-operator-core/api/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+func errorFields(errs field.ErrorList) []string {
+	var fields []string
+	for _, err := range errs {
+		fields = append(fields, string(err.Type)+" "+err.Field)
+	}
+	return fields
+}
+
+func checkErrors(t *testing.T, errs field.ErrorList, expected []string) {
+	t.Helper()
+	if got := errorFields(errs); !reflect.DeepEqual(got, expected) {
+		t.Errorf("errors = %v, want %v", errs, expected)
+	}
+}
+
+func s3Daemon(lock *types.S3ObjectLock, evictionPolicy string) types.BackupDaemon {
+	return types.BackupDaemon{
+		Storage:        types.Storage{Type: "s3"},
+		S3Storage:      &types.S3Storage{ObjectLock: lock},
+		EvictionPolicy: evictionPolicy,
+	}
+}
+
+func TestValidateBackupDaemon(t *testing.T) {
+	tests := map[string]struct {
+		daemon   types.BackupDaemon
+		expected []string
+	}{
+		"defaults": {},
+		"unknown storage type": {
+			daemon:   types.BackupDaemon{Storage: types.Storage{Type: "nfs"}},
+			expected: []string{"FieldValueNotSupported backupDaemon.storage.type"},
+		},
+		"object lock shorter than eviction": {
+			daemon: s3Daemon(&types.S3ObjectLock{Mode: "governance", Retention: "7d"}, "7d/delete"),
+		},
+		"eviction of locked backups": {
+			daemon:   s3Daemon(&types.S3ObjectLock{Mode: "compliance", Retention: "7d"}, "1d/delete"),
+			expected: []string{"FieldValueInvalid backupDaemon.evictionPolicy"},
+		},
+		"unknown object lock mode": {
+			daemon:   s3Daemon(&types.S3ObjectLock{Mode: "legal", Retention: "1d"}, ""),
+			expected: []string{"FieldValueNotSupported backupDaemon.s3Storage.objectLock.mode"},
+		},
+		"invalid object lock retention": {
+			daemon:   s3Daemon(&types.S3ObjectLock{Mode: "governance", Retention: "week"}, "1d/delete"),
+			expected: []string{"FieldValueInvalid backupDaemon.s3Storage.objectLock.retention"},
+		},
+		"invalid eviction policy with object lock": {
+			daemon: s3Daemon(&types.S3ObjectLock{Mode: "governance", Retention: "1d"}, "delete"),
+			// reported once, by eviction policy validation
+			expected: []string{"FieldValueInvalid backupDaemon.evictionPolicy"},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			checkErrors(t, ValidateBackupDaemon(&tt.daemon, field.NewPath("backupDaemon")), tt.expected)
+		})
+	}
+}
+
+func TestValidateGranularSchedules(t *testing.T) {
+	tests := map[string]struct {
+		daemon   types.BackupDaemon
+		expected []string
+	}{
+		"database": {
+			daemon: types.BackupDaemon{GranularSchedules: []types.GranularSchedule{
+				{Database: "orders", Schedule: "0 1 * * *", Retention: "7d"},
+				{DatabasePattern: "^shop_", Exclude: []string{"_tmp$"}, Schedule: "0 2 * * *"},
+			}},
+		},
+		"legacy schedule disabled": {
+			daemon: types.BackupDaemon{
+				GranularBackupSchedule: "none",
+				GranularSchedules:      []types.GranularSchedule{{Database: "orders", Schedule: "0 1 * * *"}},
+			},
+		},
+		"legacy schedule": {
+			daemon: types.BackupDaemon{
+				GranularBackupSchedule: "0 0 * * *",
+				DatabasesToSchedule:    "orders",
+				GranularSchedules:      []types.GranularSchedule{{Database: "orders", Schedule: "0 1 * * *"}},
+			},
+			expected: []string{
+				"FieldValueForbidden granularSchedules",
+				"FieldValueForbidden granularSchedules",
+			},
+		},
+		"database and pattern": {
+			daemon: types.BackupDaemon{GranularSchedules: []types.GranularSchedule{
+				{Database: "orders", DatabasePattern: "^orders", Schedule: "0 1 * * *"},
+				{Schedule: "0 1 * * *"},
+			}},
+			expected: []string{
+				"FieldValueForbidden granularSchedules[0]",
+				"FieldValueRequired granularSchedules[1]",
+			},
+		},
+		"duplicate database": {
+			daemon: types.BackupDaemon{GranularSchedules: []types.GranularSchedule{
+				{Database: "orders", Schedule: "0 1 * * *"},
+				{Database: "orders", Schedule: "0 2 * * *"},
+			}},
+			expected: []string{"FieldValueDuplicate granularSchedules[1].database"},
+		},
+		"invalid values": {
+			daemon: types.BackupDaemon{GranularSchedules: []types.GranularSchedule{
+				{DatabasePattern: "(", Exclude: []string{"["}, Schedule: "daily", Retention: "week"},
+				{Database: "orders"},
+			}},
+			expected: []string{
+				"FieldValueInvalid granularSchedules[0].databasePattern",
+				"FieldValueInvalid granularSchedules[0].exclude[0]",
+				"FieldValueInvalid granularSchedules[0].schedule",
+				"FieldValueInvalid granularSchedules[0].retention",
+				"FieldValueRequired granularSchedules[1].schedule",
+			},
+		},
+		"backup longer than schedule interval": {
+			daemon: types.BackupDaemon{
+				BackupTimeout:     2 * 60 * 60,
+				GranularSchedules: []types.GranularSchedule{{Database: "orders", Schedule: "0 * * * *"}},
+			},
+			expected: []string{"FieldValueInvalid granularSchedules[0].schedule"},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			checkErrors(t, validateGranularSchedules(&tt.daemon, field.NewPath("granularSchedules")), tt.expected)
+		})
+	}
+}
+
+func TestValidateSizeRetention(t *testing.T) {
+	locked := s3Daemon(&types.S3ObjectLock{Mode: "governance", Retention: "7d"}, "")
+	locked.SizeRetention = &types.SizeRetention{Quota: "100Gi"}
+	tests := map[string]struct {
+		daemon   types.BackupDaemon
+		expected []string
+	}{
+		"disabled": {},
+		"quota": {
+			daemon: types.BackupDaemon{SizeRetention: &types.SizeRetention{Quota: "100Gi", MinFullBackups: 2}},
+		},
+		"percent of storage": {
+			daemon: types.BackupDaemon{
+				Storage:       types.Storage{Type: "provisioned", Size: "200Gi"},
+				SizeRetention: &types.SizeRetention{MaxPercent: 80},
+			},
+		},
+		"empty": {
+			daemon:   types.BackupDaemon{SizeRetention: &types.SizeRetention{}},
+			expected: []string{"FieldValueRequired sizeRetention"},
+		},
+		"invalid quota": {
+			daemon: types.BackupDaemon{SizeRetention: &types.SizeRetention{Quota: "lots", MinFullBackups: -1}},
+			expected: []string{
+				"FieldValueInvalid sizeRetention",
+				"FieldValueInvalid sizeRetention.minFullBackups",
+			},
+		},
+		"s3 object lock": {
+			daemon:   locked,
+			expected: []string{"FieldValueForbidden sizeRetention"},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			checkErrors(t, validateSizeRetention(&tt.daemon, field.NewPath("sizeRetention")), tt.expected)
+		})
+	}
+}
+
+func TestValidateNames(t *testing.T) {
+	url := &corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "webhooks"}, Key: "url"}
+	pvTarget := func(name string) types.BackupCopyTarget {
+		return types.BackupCopyTarget{Name: name, Type: "pv", ClaimName: "backups-copy"}
+	}
+	sqlHook := func(name string) types.BackupHook {
+		return types.BackupHook{Name: name, Sql: []string{"CHECKPOINT"}}
+	}
+	tests := map[string]struct {
+		daemon   types.BackupDaemon
+		expected []string
+	}{
+		"unique names": {
+			daemon: types.BackupDaemon{
+				CopyTargets:   []types.BackupCopyTarget{pvTarget("dr"), pvTarget("archive")},
+				Hooks:         &types.BackupHooks{PreBackup: []types.BackupHook{sqlHook("checkpoint")}, PostBackup: []types.BackupHook{sqlHook("checkpoint")}},
+				Notifications: &types.BackupNotifications{Targets: []types.NotificationTarget{{Name: "ops", Type: "slack", Url: url}}},
+			},
+		},
+		"missing names": {
+			daemon: types.BackupDaemon{
+				CopyTargets:   []types.BackupCopyTarget{pvTarget("")},
+				Hooks:         &types.BackupHooks{PreBackup: []types.BackupHook{sqlHook("")}},
+				Notifications: &types.BackupNotifications{Targets: []types.NotificationTarget{{Type: "slack", Url: url}}},
+			},
+			expected: []string{
+				"FieldValueRequired backupDaemon.copyTargets[0].name",
+				"FieldValueRequired backupDaemon.hooks.preBackup[0].name",
+				"FieldValueRequired backupDaemon.notifications.targets[0].name",
+			},
+		},
+		"duplicate names": {
+			daemon: types.BackupDaemon{
+				CopyTargets: []types.BackupCopyTarget{pvTarget("dr"), pvTarget("dr")},
+				Hooks:       &types.BackupHooks{PostBackup: []types.BackupHook{sqlHook("notify"), sqlHook("notify")}},
+				Notifications: &types.BackupNotifications{Targets: []types.NotificationTarget{
+					{Name: "ops", Type: "slack", Url: url},
+					{Name: "ops", Type: "teams", Url: url},
+				}},
+			},
+			expected: []string{
+				"FieldValueDuplicate backupDaemon.copyTargets[1].name",
+				"FieldValueDuplicate backupDaemon.hooks.postBackup[1].name",
+				"FieldValueDuplicate backupDaemon.notifications.targets[1].name",
+			},
+		},
+		"names used in paths": {
+			daemon: types.BackupDaemon{
+				CopyTargets:   []types.BackupCopyTarget{pvTarget("../backups"), pvTarget("dr/eu")},
+				Hooks:         &types.BackupHooks{PreBackup: []types.BackupHook{sqlHook("Checkpoint")}},
+				Notifications: &types.BackupNotifications{Targets: []types.NotificationTarget{{Name: "on_call", Type: "slack", Url: url}}},
+			},
+			expected: []string{
+				"FieldValueInvalid backupDaemon.copyTargets[0].name",
+				"FieldValueInvalid backupDaemon.copyTargets[1].name",
+				"FieldValueInvalid backupDaemon.hooks.preBackup[0].name",
+				"FieldValueInvalid backupDaemon.notifications.targets[0].name",
+			},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			checkErrors(t, ValidateBackupDaemon(&tt.daemon, field.NewPath("backupDaemon")), tt.expected)
+		})
+	}
+}